@@ -116,9 +116,9 @@ func (c *Client) ExchangeCodeForToken(ctx context.Context, code, expectedState,
 		"code":          {code},
 	}
 
-	resp, err := c.httpClient.POST("/oauth/access_token", data, "")
+	resp, err := c.httpClient.POSTWithContext(ctx, "/oauth/access_token", data, "")
 	if err != nil {
-		return NewNetworkError(0, "Failed to exchange code for token", err.Error(), true)
+		return NewNetworkErrorWithCause(0, "Failed to exchange code for token", err.Error(), true, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -149,7 +149,7 @@ func (c *Client) ExchangeCodeForToken(ctx context.Context, code, expectedState,
 	}
 
 	// Store the token using thread-safe method
-	if err := c.SetTokenInfo(tokenInfo); err != nil {
+	if err := c.setTokenInfo(tokenInfo, TokenEventExchanged, "authorization code exchanged"); err != nil {
 		if c.config.Logger != nil {
 			c.config.Logger.Warn("Failed to store token", "error", err.Error())
 		}
@@ -185,9 +185,9 @@ func (c *Client) GetLongLivedToken(ctx context.Context) error {
 		"access_token":  {currentToken},
 	}
 
-	resp, err := c.httpClient.GET("/access_token", params, currentToken)
+	resp, err := c.httpClient.GETWithContext(ctx, "/access_token", params, currentToken)
 	if err != nil {
-		return NewNetworkError(0, "Failed to get long-lived token", err.Error(), true)
+		return NewNetworkErrorWithCause(0, "Failed to get long-lived token", err.Error(), true, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -226,7 +226,7 @@ func (c *Client) GetLongLivedToken(ctx context.Context) error {
 	}
 
 	// Store the token using thread-safe method
-	if err := c.SetTokenInfo(tokenInfo); err != nil {
+	if err := c.setTokenInfo(tokenInfo, TokenEventLongLived, "exchanged for long-lived token"); err != nil {
 		if c.config.Logger != nil {
 			c.config.Logger.Warn("Failed to store long-lived token", "error", err.Error())
 		}
@@ -247,7 +247,71 @@ func (c *Client) GetLongLivedToken(ctx context.Context) error {
 // This extends the validity of your existing token without requiring user re-authorization.
 // The refreshed token automatically replaces the current token in storage.
 // Note: Only long-lived tokens can be refreshed.
+//
+// Concurrent calls are deduplicated: while a refresh is in flight, further
+// callers wait for and share its result instead of issuing their own request.
+// The shared refresh is only cancelled once every caller waiting for it has
+// had its context cancelled; it is also bounded by DefaultTokenRefreshTimeout.
 func (c *Client) RefreshToken(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.refreshMu.Lock()
+	call := c.refreshCall
+	if call == nil {
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), DefaultTokenRefreshTimeout)
+		call = &refreshCall{done: make(chan struct{}), cancel: cancel}
+		c.refreshCall = call
+		go c.runRefreshCall(refreshCtx, call)
+	}
+	call.waiters++
+	c.refreshMu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		c.refreshMu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody is waiting for the result any more; abort the request
+			// and let the next caller start a new refresh
+			call.cancel()
+			if c.refreshCall == call {
+				c.refreshCall = nil
+			}
+		}
+		c.refreshMu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// runRefreshCall performs the shared refresh for call on ctx, which keeps the
+// values of the starting caller's context but not its cancellation.
+func (c *Client) runRefreshCall(ctx context.Context, call *refreshCall) {
+	defer call.cancel()
+
+	call.err = c.refreshToken(ctx)
+
+	c.refreshMu.Lock()
+	if c.refreshCall == call {
+		c.refreshCall = nil
+	}
+	c.refreshMu.Unlock()
+	close(call.done)
+}
+
+// refreshCall tracks an in-flight RefreshToken so concurrent callers can share it.
+type refreshCall struct {
+	done    chan struct{}
+	err     error
+	cancel  context.CancelFunc
+	waiters int // Callers waiting for the result; guarded by Client.refreshMu
+}
+
+// refreshToken performs the refresh request and stores the new token.
+func (c *Client) refreshToken(ctx context.Context) error {
 	c.mu.RLock()
 	currentToken := c.accessToken
 	c.mu.RUnlock()
@@ -261,9 +325,9 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 		"access_token": {currentToken},
 	}
 
-	resp, err := c.httpClient.GET("/refresh_access_token", params, "")
+	resp, err := c.httpClient.GETWithContext(ctx, "/refresh_access_token", params, "")
 	if err != nil {
-		return NewNetworkError(0, "Failed to refresh token", err.Error(), true)
+		return NewNetworkErrorWithCause(0, "Failed to refresh token", err.Error(), true, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -301,8 +365,13 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 		CreatedAt:   now,
 	}
 
+	// A refresh abandoned by its callers must not replace the token
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Store the token using thread-safe method
-	if err := c.SetTokenInfo(tokenInfo); err != nil {
+	if err := c.setTokenInfo(tokenInfo, TokenEventRefreshed, "long-lived token refreshed"); err != nil {
 		if c.config.Logger != nil {
 			c.config.Logger.Warn("Failed to store refreshed token", "error", err.Error())
 		}
@@ -474,9 +543,9 @@ func (c *Client) DebugToken(ctx context.Context, inputToken string) (*DebugToken
 		"access_token": {accessToken},
 	}
 
-	resp, err := c.httpClient.GET("/debug_token", params, accessToken)
+	resp, err := c.httpClient.GETWithContext(ctx, "/debug_token", params, accessToken)
 	if err != nil {
-		return nil, NewNetworkError(0, "Failed to debug token", err.Error(), true)
+		return nil, NewNetworkErrorWithCause(0, "Failed to debug token", err.Error(), true, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -515,9 +584,9 @@ func (c *Client) GetAppAccessToken(ctx context.Context) (*AppAccessTokenResponse
 		"grant_type":    {"client_credentials"},
 	}
 
-	resp, err := c.httpClient.GET("/oauth/access_token", params, "")
+	resp, err := c.httpClient.GETWithContext(ctx, "/oauth/access_token", params, "")
 	if err != nil {
-		return nil, NewNetworkError(0, "Failed to get app access token", err.Error(), true)
+		return nil, NewNetworkErrorWithCause(0, "Failed to get app access token", err.Error(), true, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
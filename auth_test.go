@@ -2,6 +2,7 @@ package threads
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -176,6 +177,31 @@ func TestRefreshToken_NoToken(t *testing.T) {
 	}
 }
 
+func TestRefreshToken_ContextCancelled(t *testing.T) {
+	aborted := make(chan struct{})
+	client := testClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(aborted)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.RefreshToken(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("expected the refresh request to be cancelled on the server")
+	}
+	if client.GetAccessToken() != "test-access-token" {
+		t.Error("token should be unchanged after a cancelled refresh")
+	}
+}
+
 func TestDebugToken_Success(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{
 		"data": {
@@ -49,6 +49,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -68,6 +69,21 @@ type Client struct {
 	tokenInfo    *TokenInfo
 	tokenStorage TokenStorage
 	mu           sync.RWMutex // Protects token-related fields
+
+	refreshMu   sync.Mutex   // Protects refreshCall and autoRefresh
+	refreshCall *refreshCall // In-flight RefreshToken shared by concurrent callers
+	autoRefresh *autoRefresher
+
+	quotaGuard *quotaGuard // Opt-in publishing quota checks; protected by mu
+
+	idempotencyStore IdempotencyStore // Records for WithIdempotencyKey
+	idempotencyLocks keyedMutex       // Serializes calls sharing an idempotency key
+
+	hostedMu    sync.Mutex
+	hostedMedia map[string]hostedUpload // Hosted media by container ID
+
+	preparedMu sync.Mutex
+	prepared   map[string]preparedContainer // Containers created by Prepare*Post by ID
 }
 
 // Config holds configuration settings for the Threads API client.
@@ -106,6 +122,18 @@ type Config struct {
 	// Default: 30 seconds. Set to 0 for no timeout (not recommended).
 	HTTPTimeout time.Duration
 
+	// HTTPClient is the underlying *http.Client used for all requests (optional).
+	// Supply one to plug in a custom Transport (proxies, mTLS, connection pool
+	// tuning, recording/replay in tests). When set, it is used as-is and
+	// HTTPTimeout is not applied; configure Timeout on the supplied client.
+	// If nil, a client with HTTPTimeout and the default transport is created.
+	HTTPClient *http.Client
+
+	// Middleware is a chain of interceptors applied to every HTTP attempt,
+	// including retries (optional). The first entry is the outermost wrapper.
+	// See Middleware for details.
+	Middleware []Middleware
+
 	// RetryConfig configures retry behavior for failed requests (optional).
 	// If nil, default retry configuration will be used.
 	RetryConfig *RetryConfig
@@ -117,9 +145,47 @@ type Config struct {
 
 	// TokenStorage provides persistent token storage (optional).
 	// If nil, tokens will be stored in memory only and lost when the client
-	// is destroyed. Implement the TokenStorage interface for persistence.
+	// is destroyed. Use FileTokenStorage (optionally encrypted) or implement
+	// the TokenStorage interface for persistence.
 	TokenStorage TokenStorage
 
+	// OnTokenEvent is called after the access token is set, exchanged,
+	// upgraded, refreshed, cleared, or rejected by the API as invalid
+	// (optional). Tokens in the event are redacted. The callback runs
+	// synchronously on the goroutine that changed the token, so it should
+	// return quickly.
+	OnTokenEvent func(event TokenEvent)
+
+	// RateLimitStore shares "rate limited until" state with other clients
+	// using the same app and account, such as replicas of a service
+	// (optional). Use FileRateLimitStore or implement RateLimitStore.
+	// If nil, rate limit state is kept in memory by this client only.
+	RateLimitStore RateLimitStore
+
+	// AppRateLimitStore shares throttling reported by X-App-Usage, which
+	// applies to every account of the app, with clients of other accounts
+	// (optional). ClientPool provides one to its clients by default.
+	AppRateLimitStore RateLimitStore
+
+	// IdempotencyStore records the containers and posts created by calls made
+	// with WithIdempotencyKey (optional). Supply a durable store to make those
+	// calls safe to retry across restarts or replicas.
+	// If nil, records are kept in memory for DefaultIdempotencyTTL.
+	IdempotencyStore IdempotencyStore
+
+	// ContainerPolling controls how long and how often the client polls
+	// media containers before publishing them (optional). Override it per
+	// call with WithContainerPolling.
+	// If nil, containers are checked every DefaultContainerPollInterval up
+	// to DefaultContainerPollMaxAttempts times.
+	ContainerPolling *ContainerPollConfig
+
+	// MediaHost makes local media files public so they can be posted with
+	// ImagePath, ImageReader, VideoPath, VideoReader or local CarouselItems
+	// (optional). Use TempMediaServer or implement MediaHost.
+	// If nil, media can only be posted by URL.
+	MediaHost MediaHost
+
 	// BaseURL is the base URL for the Threads API (optional).
 	// Default: "https://graph.threads.net". Only change this for testing
 	// or if using a proxy/gateway.
@@ -148,11 +214,19 @@ type RetryConfig struct {
 
 	// MaxDelay is the maximum delay between retry attempts (default: 30 seconds).
 	// This prevents exponential backoff from creating excessively long delays.
+	// When a rate limited response asks for a longer Retry-After, the
+	// *RateLimitError is returned instead of waiting.
 	MaxDelay time.Duration
 
 	// BackoffFactor is the multiplier for exponential backoff (default: 2.0).
 	// Each retry delay is calculated as: min(InitialDelay * BackoffFactor^attempt, MaxDelay)
 	BackoffFactor float64
+
+	// Jitter randomizes retry delays so that clients failing together do not
+	// retry in lockstep (default: 0.2). Backoff delays are reduced by up to
+	// this fraction; Retry-After delays are extended by up to this fraction.
+	// Must be between 0 and 1; 0 disables jitter.
+	Jitter float64
 }
 
 // Logger interface for structured logging.
@@ -175,7 +249,8 @@ type Logger interface {
 }
 
 // TokenStorage interface for storing and retrieving tokens.
-// The default MemoryTokenStorage loses tokens when the application terminates.
+// The default MemoryTokenStorage loses tokens when the application terminates;
+// FileTokenStorage persists them to disk.
 type TokenStorage interface {
 	// Store saves a token to persistent storage.
 	// Should return an error if the token cannot be saved.
@@ -243,6 +318,7 @@ func NewConfig() *Config {
 			InitialDelay:  1 * time.Second,
 			MaxDelay:      30 * time.Second,
 			BackoffFactor: 2.0,
+			Jitter:        0.2,
 		},
 		BaseURL:   "https://graph.threads.net",
 		UserAgent: DefaultUserAgent,
@@ -328,6 +404,12 @@ func NewConfigFromEnv() (*Config, error) {
 		}
 	}
 
+	if retryJitter := os.Getenv("THREADS_RETRY_JITTER"); retryJitter != "" {
+		if jitter, err := strconv.ParseFloat(retryJitter, 64); err == nil && jitter >= 0 && jitter <= 1 {
+			config.RetryConfig.Jitter = jitter
+		}
+	}
+
 	return config, nil
 }
 
@@ -398,6 +480,16 @@ func (c *Config) Validate() error {
 		if c.RetryConfig.InitialDelay > c.RetryConfig.MaxDelay {
 			return fmt.Errorf("RetryConfig.InitialDelay cannot be greater than MaxDelay")
 		}
+
+		if c.RetryConfig.Jitter < 0 || c.RetryConfig.Jitter > 1 {
+			return fmt.Errorf("RetryConfig.Jitter must be between 0 and 1")
+		}
+	}
+
+	if c.ContainerPolling != nil {
+		if err := c.ContainerPolling.validate(); err != nil {
+			return err
+		}
 	}
 
 	if c.BaseURL == "" {
@@ -427,6 +519,7 @@ func (c *Config) SetDefaults() {
 			InitialDelay:  1 * time.Second,
 			MaxDelay:      30 * time.Second,
 			BackoffFactor: 2.0,
+			Jitter:        0.2,
 		}
 	}
 
@@ -460,6 +553,11 @@ func NewClient(config *Config) (*Client, error) {
 		tokenStorage = &MemoryTokenStorage{}
 	}
 
+	idempotencyStore := config.IdempotencyStore
+	if idempotencyStore == nil {
+		idempotencyStore = NewMemoryIdempotencyStore(0)
+	}
+
 	// Create rate limiter
 	rateLimiterConfig := &RateLimiterConfig{
 		InitialLimit:      100, // Default limit, will be updated from API responses
@@ -467,6 +565,8 @@ func NewClient(config *Config) (*Client, error) {
 		MaxBackoff:        5 * time.Minute,
 		QueueSize:         100,
 		Logger:            config.Logger,
+		Store:             config.RateLimitStore,
+		AppStore:          config.AppRateLimitStore,
 	}
 	rateLimiter := NewRateLimiter(rateLimiterConfig)
 
@@ -479,7 +579,11 @@ func NewClient(config *Config) (*Client, error) {
 		rateLimiter:  rateLimiter,
 		baseURL:      config.BaseURL,
 		tokenStorage: tokenStorage,
+
+		idempotencyStore: idempotencyStore,
 	}
+	httpClient.onInvalidToken = client.handleInvalidToken
+	httpClient.onEndpointUse = client.recordQuotaUse
 
 	// Try to load existing token from storage
 	if tokenInfo, err := tokenStorage.Load(); err == nil {
@@ -542,19 +646,29 @@ func NewClientWithToken(accessToken string, config *Config) (*Client, error) {
 
 // SetTokenInfo sets the token information in a thread-safe manner
 func (c *Client) SetTokenInfo(tokenInfo *TokenInfo) error {
+	return c.setTokenInfo(tokenInfo, TokenEventSet, "token set by caller")
+}
+
+// setTokenInfo replaces the token, persists it, and reports the change to
+// Config.OnTokenEvent as eventType.
+func (c *Client) setTokenInfo(tokenInfo *TokenInfo, eventType TokenEventType, reason string) error {
 	if tokenInfo == nil {
 		return fmt.Errorf("tokenInfo cannot be nil")
 	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
+	oldToken := c.tokenInfo
 	c.tokenInfo = tokenInfo
 	c.accessToken = tokenInfo.AccessToken
 
 	// Store the token using the configured storage
-	if err := c.tokenStorage.Store(tokenInfo); err != nil {
-		return fmt.Errorf("failed to store token: %w", err)
+	storeErr := c.tokenStorage.Store(tokenInfo)
+	c.mu.Unlock()
+
+	c.emitTokenEvent(eventType, reason, oldToken, tokenInfo, nil)
+
+	if storeErr != nil {
+		return fmt.Errorf("failed to store token: %w", storeErr)
 	}
 
 	return nil
@@ -644,14 +758,18 @@ func (c *Client) EnsureValidToken(ctx context.Context) error {
 // ClearToken removes the current token from the client and storage
 func (c *Client) ClearToken() error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
+	oldToken := c.tokenInfo
 	c.accessToken = ""
 	c.tokenInfo = nil
 
 	// Clear from storage
-	if err := c.tokenStorage.Delete(); err != nil {
-		return fmt.Errorf("failed to clear token from storage: %w", err)
+	deleteErr := c.tokenStorage.Delete()
+	c.mu.Unlock()
+
+	c.emitTokenEvent(TokenEventCleared, "token cleared by caller", oldToken, nil, nil)
+
+	if deleteErr != nil {
+		return fmt.Errorf("failed to clear token from storage: %w", deleteErr)
 	}
 
 	return nil
@@ -787,6 +905,8 @@ func (c *Client) EnableRateLimiting() {
 			MaxBackoff:        5 * time.Minute,
 			QueueSize:         100,
 			Logger:            c.config.Logger,
+			Store:             c.config.RateLimitStore,
+			AppStore:          c.config.AppRateLimitStore,
 		}
 		c.rateLimiter = NewRateLimiter(rateLimiterConfig)
 	}
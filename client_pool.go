@@ -0,0 +1,153 @@
+package threads
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ClientPool manages Clients for many Threads accounts that share one app
+// configuration. Clients are built lazily on first use from tokens held in a
+// MultiTokenStorage.
+//
+// All pooled clients share a single *http.Client, and therefore one
+// connection pool, but each keeps its own token, RateLimiter and refresh
+// state, so throttling or re-authenticating one account never affects
+// another. ClientPool is safe for concurrent use.
+type ClientPool struct {
+	config     *Config
+	storage    MultiTokenStorage
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	clients map[UserID]*Client
+}
+
+// NewClientPool creates a pool from a template configuration. The template's
+// TokenStorage and RateLimitStore are ignored, since both hold per-account
+// state; each account's tokens are kept in storage, which defaults to a
+// MemoryMultiTokenStorage when nil. App-level throttling is shared by all
+// accounts through config.AppRateLimitStore, which defaults to a
+// MemoryRateLimitStore shared by the pool's clients. If config.HTTPClient is
+// nil, a shared client with config.HTTPTimeout is created.
+func NewClientPool(config *Config, storage MultiTokenStorage) (*ClientPool, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	template := *config
+	template.SetDefaults()
+	if err := template.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if storage == nil {
+		storage = NewMemoryMultiTokenStorage()
+	}
+
+	httpClient := template.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: template.HTTPTimeout}
+	}
+	template.HTTPClient = httpClient
+	template.TokenStorage = nil
+	template.RateLimitStore = nil
+	if template.AppRateLimitStore == nil {
+		template.AppRateLimitStore = NewMemoryRateLimitStore()
+	}
+
+	return &ClientPool{
+		config:     &template,
+		storage:    storage,
+		httpClient: httpClient,
+		clients:    make(map[UserID]*Client),
+	}, nil
+}
+
+// Client returns the Client for userID, building it on first use. It returns
+// an error if no token is stored for the account.
+func (p *ClientPool) Client(userID UserID) (*Client, error) {
+	if !userID.Valid() {
+		return nil, NewValidationError(400, "User ID is required", "Cannot look up a pooled client without a user ID", "user_id")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[userID]; ok {
+		return client, nil
+	}
+
+	if _, err := p.storage.LoadToken(userID); err != nil {
+		return nil, err
+	}
+	return p.newClientLocked(userID)
+}
+
+// AddAccount stores token under token.UserID and returns the account's
+// Client. An existing Client for the account is updated in place.
+func (p *ClientPool) AddAccount(token *TokenInfo) (*Client, error) {
+	if token == nil {
+		return nil, NewValidationError(400, "Token is required", "Cannot add an account without a token", "token")
+	}
+	userID := ConvertToUserID(token.UserID)
+	if !userID.Valid() {
+		return nil, NewValidationError(400, "User ID is required", "TokenInfo.UserID must be set to add an account", "user_id")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	client, ok := p.clients[userID]
+	if !ok {
+		var err error
+		if client, err = p.newClientLocked(userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := client.SetTokenInfo(token); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// RemoveAccount deletes the stored token for userID and drops its Client,
+// stopping the Client's auto-refresh so it cannot store the token again.
+func (p *ClientPool) RemoveAccount(userID UserID) error {
+	p.mu.Lock()
+	client := p.clients[userID]
+	delete(p.clients, userID)
+	p.mu.Unlock()
+
+	if client != nil {
+		client.StopAutoRefresh()
+	}
+
+	if err := p.storage.DeleteToken(userID); err != nil {
+		return fmt.Errorf("failed to delete token for user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// Accounts returns the IDs of all accounts with a stored token.
+func (p *ClientPool) Accounts() ([]UserID, error) {
+	return p.storage.ListUserIDs()
+}
+
+// HTTPClient returns the *http.Client shared by every pooled Client.
+func (p *ClientPool) HTTPClient() *http.Client {
+	return p.httpClient
+}
+
+// newClientLocked builds and caches the Client for userID. Callers must hold p.mu.
+func (p *ClientPool) newClientLocked(userID UserID) (*Client, error) {
+	config := *p.config
+	config.TokenStorage = NewAccountTokenStorage(p.storage, userID)
+
+	client, err := NewClient(&config)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[userID] = client
+	return client, nil
+}
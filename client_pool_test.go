@@ -0,0 +1,226 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func poolTestToken(userID string) *TokenInfo {
+	return &TokenInfo{
+		AccessToken: "token-" + userID,
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+		UserID:      userID,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestClientPool_PerAccountClientsShareHTTPClient(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/")
+		mu.Lock()
+		seen[id] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"` + id + `","username":"user` + id + `"}`))
+	}))
+	defer server.Close()
+
+	config := testClientConfig(t, http.NotFoundHandler())
+	config.BaseURL = server.URL
+	pool, err := NewClientPool(config, nil)
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	alice, err := pool.AddAccount(poolTestToken("111"))
+	if err != nil {
+		t.Fatalf("AddAccount: %v", err)
+	}
+	if _, err := pool.AddAccount(poolTestToken("222")); err != nil {
+		t.Fatalf("AddAccount: %v", err)
+	}
+
+	bob, err := pool.Client("222")
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	if again, _ := pool.Client("111"); again != alice {
+		t.Error("expected the pool to reuse the cached client")
+	}
+
+	for _, c := range []*Client{alice, bob} {
+		if _, err := c.GetMe(context.Background()); err != nil {
+			t.Fatalf("GetMe: %v", err)
+		}
+		if c.httpClient.client != pool.HTTPClient() {
+			t.Error("expected pooled clients to share one *http.Client")
+		}
+	}
+	if seen["111"] != "Bearer token-111" || seen["222"] != "Bearer token-222" {
+		t.Errorf("expected per-account tokens, got %v", seen)
+	}
+
+	alice.rateLimiter.MarkRateLimited(time.Now().Add(time.Minute))
+	if !alice.IsRateLimited() || bob.IsRateLimited() {
+		t.Error("expected rate limiter state to be per account")
+	}
+
+	accounts, err := pool.Accounts()
+	if err != nil || len(accounts) != 2 {
+		t.Fatalf("expected 2 accounts, got %v (%v)", accounts, err)
+	}
+}
+
+func TestClientPool_SharesAppThrottling(t *testing.T) {
+	pool, err := NewClientPool(testClientConfig(t, http.NotFoundHandler()), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, _ := pool.AddAccount(poolTestToken("111"))
+	bob, _ := pool.AddAccount(poolTestToken("222"))
+
+	// Business use case throttling only affects the account it was reported for
+	alice.rateLimiter.UpdateUsage(&APIUsage{BusinessUseCase: map[string][]BusinessUseCaseUsage{
+		"111": {{CallCount: 100, EstimatedTimeToRegainAccess: time.Minute}},
+	}})
+	if !alice.IsRateLimited() || bob.IsRateLimited() {
+		t.Fatal("expected business use case throttling to stay per account")
+	}
+
+	alice.rateLimiter.UpdateUsage(&APIUsage{App: &AppUsage{CallCount: 100}})
+	if !bob.IsRateLimited() {
+		t.Error("expected app-level throttling to apply to every account of the pool")
+	}
+}
+
+func TestClientPool_LazyLoadAndRemove(t *testing.T) {
+	storage := NewMemoryMultiTokenStorage()
+	if err := storage.StoreToken("333", poolTestToken("333")); err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err := NewClientPool(testClientConfig(t, http.NotFoundHandler()), storage)
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+
+	client, err := pool.Client("333")
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+	if client.GetAccessToken() != "token-333" {
+		t.Errorf("expected token loaded from storage, got %q", client.GetAccessToken())
+	}
+
+	if _, err := pool.Client("444"); !IsAuthenticationError(err) {
+		t.Errorf("expected authentication error for unknown account, got %v", err)
+	}
+
+	if err := pool.RemoveAccount("333"); err != nil {
+		t.Fatalf("RemoveAccount: %v", err)
+	}
+	if _, err := pool.Client("333"); err == nil {
+		t.Error("expected removed account to be unavailable")
+	}
+}
+
+func TestClientPool_RemoveAccountStopsAutoRefresh(t *testing.T) {
+	storage := NewMemoryMultiTokenStorage()
+	pool, err := NewClientPool(testClientConfig(t, http.NotFoundHandler()), storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := pool.AddAccount(poolTestToken("666"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.StartAutoRefresh(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pool.RemoveAccount("666"); err != nil {
+		t.Fatal(err)
+	}
+	client.refreshMu.Lock()
+	running := client.autoRefresh != nil
+	client.refreshMu.Unlock()
+	if running {
+		t.Error("expected auto-refresh of the removed account to be stopped")
+	}
+	if _, err := storage.LoadToken("666"); err == nil {
+		t.Error("expected the removed account's token to be deleted")
+	}
+}
+
+func TestClientPool_TokenUpdatesPersistPerAccount(t *testing.T) {
+	storage := NewMemoryMultiTokenStorage()
+	pool, err := NewClientPool(testClientConfig(t, http.NotFoundHandler()), storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := pool.AddAccount(poolTestToken("555"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated := poolTestToken("555")
+	updated.AccessToken = "refreshed"
+	if err := client.SetTokenInfo(updated); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := storage.LoadToken("555")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.AccessToken != "refreshed" {
+		t.Errorf("expected the refreshed token to be stored for the account, got %q", stored.AccessToken)
+	}
+}
+
+func TestFileMultiTokenStorage(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewEncryptedFileMultiTokenStorage(dir, []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []UserID{"2", "1"} {
+		if err := storage.StoreToken(id, poolTestToken(id.String())); err != nil {
+			t.Fatalf("StoreToken: %v", err)
+		}
+	}
+
+	ids, err := storage.ListUserIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("expected [1 2], got %v", ids)
+	}
+
+	reopened, err := NewEncryptedFileMultiTokenStorage(dir, []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := reopened.LoadToken("2")
+	if err != nil {
+		t.Fatalf("LoadToken: %v", err)
+	}
+	if token.AccessToken != "token-2" {
+		t.Errorf("expected token-2, got %q", token.AccessToken)
+	}
+
+	if err := storage.StoreToken("../escape", poolTestToken("x")); !IsValidationError(err) {
+		t.Errorf("expected path-like user IDs to be rejected, got %v", err)
+	}
+}
@@ -1068,6 +1068,7 @@ func clearThreadsEnv(t *testing.T) {
 		"THREADS_SCOPES", "THREADS_HTTP_TIMEOUT", "THREADS_BASE_URL",
 		"THREADS_USER_AGENT", "THREADS_DEBUG", "THREADS_MAX_RETRIES",
 		"THREADS_INITIAL_DELAY", "THREADS_MAX_DELAY", "THREADS_BACKOFF_FACTOR",
+		"THREADS_RETRY_JITTER",
 	} {
 		t.Setenv(key, "")
 	}
@@ -1129,6 +1130,7 @@ func TestNewConfigFromEnv(t *testing.T) {
 		t.Setenv("THREADS_INITIAL_DELAY", "2s")
 		t.Setenv("THREADS_MAX_DELAY", "60s")
 		t.Setenv("THREADS_BACKOFF_FACTOR", "3.0")
+		t.Setenv("THREADS_RETRY_JITTER", "0.5")
 
 		config, err := NewConfigFromEnv()
 		if err != nil {
@@ -1168,6 +1170,9 @@ func TestNewConfigFromEnv(t *testing.T) {
 		if config.RetryConfig.BackoffFactor != 3.0 {
 			t.Errorf("Expected BackoffFactor 3.0, got %v", config.RetryConfig.BackoffFactor)
 		}
+		if config.RetryConfig.Jitter != 0.5 {
+			t.Errorf("Expected Jitter 0.5, got %v", config.RetryConfig.Jitter)
+		}
 	})
 }
 
@@ -1867,7 +1872,7 @@ func TestWaitForContainerReadyRespectsContext(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
 	defer cancel()
 
-	err = client.waitForContainerReady(ctx, ContainerID("fake-id"), 100, 1*time.Second)
+	err = client.waitForContainerReady(ctx, ContainerID("fake-id"), ContainerPollConfig{MaxAttempts: 100, InitialInterval: 1 * time.Second, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("Expected error when context times out")
 	}
@@ -3,7 +3,6 @@ package threads
 import (
 	"encoding/json"
 	"fmt"
-	"time"
 )
 
 // getUserID extracts user ID from token info
@@ -44,16 +43,12 @@ func (c *Client) handleAPIError(resp *Response) error {
 
 			// Return appropriate error type based on status code
 			var resultErr error
-			switch resp.StatusCode {
-			case 401, 403:
+			switch {
+			case resp.StatusCode == 429 || isThrottlingErrorCode(errorCode):
+				resultErr = NewRateLimitError(errorCode, message, details, retryAfterFromResponse(resp))
+			case resp.StatusCode == 401 || resp.StatusCode == 403 || errorCode == ErrorCodeInvalidOAuthToken:
 				resultErr = NewAuthenticationError(errorCode, message, details)
-			case 429:
-				var retryAfter time.Duration
-				if resp.RateLimit != nil {
-					retryAfter = resp.RateLimit.RetryAfter
-				}
-				resultErr = NewRateLimitError(errorCode, message, details, retryAfter)
-			case 400, 422:
+			case resp.StatusCode == 400 || resp.StatusCode == 422:
 				resultErr = NewValidationError(errorCode, message, details, "")
 			default:
 				resultErr = NewAPIError(errorCode, message, details, resp.RequestID)
@@ -44,6 +44,52 @@ const (
 	DefaultUserAgent   = "threads-go/" + Version
 )
 
+// Token refresh timing
+const (
+	MinTokenAgeForRefresh           = 24 * time.Hour     // Long-lived tokens can only be refreshed once at least this old
+	DefaultAutoRefreshBefore        = 7 * 24 * time.Hour // Refresh this long before expiry
+	DefaultAutoRefreshCheckInterval = time.Hour          // Maximum time between expiry checks
+	DefaultAutoRefreshRetryInterval = 5 * time.Minute    // Delay before retrying a failed refresh
+	DefaultTokenRefreshTimeout      = 2 * time.Minute    // Maximum duration of a shared RefreshToken call
+)
+
+// Quota guard
+const (
+	DefaultQuotaGuardTTL           = 5 * time.Minute // How long cached publishing limits are trusted
+	DefaultQuotaGuardRetryInterval = time.Minute     // Delay before fetching limits again after a failed fetch
+)
+
+// Idempotent publishing
+const (
+	DefaultIdempotencyTTL = 24 * time.Hour // How long idempotency records are kept; containers expire after 24 hours
+)
+
+// Scheduled publishing
+const (
+	DefaultSchedulerPollInterval  = time.Minute    // Maximum time between checks for due jobs
+	DefaultSchedulerMaxAttempts   = 5              // Attempts made to publish a job with transient failures
+	DefaultSchedulerRetryDelay    = time.Minute    // Delay before the first retry; doubles with each attempt
+	DefaultSchedulerMaxRetryDelay = 24 * time.Hour // Maximum delay between attempts
+)
+
+// Local media hosting
+const (
+	DefaultTempMediaMaxAge = 24 * time.Hour // How long TempMediaServer serves a file that is never cleaned up
+)
+
+// Graph API error codes
+const (
+	ErrorCodeInvalidOAuthToken = 190 // Access token is invalid, expired or revoked
+
+	// Throttling codes, returned with HTTP 400 or 403 as well as 429
+	ErrorCodeAppRateLimit         = 4     // Application request limit reached
+	ErrorCodeUserRateLimit        = 17    // User request limit reached
+	ErrorCodePageRateLimit        = 32    // Page-level request limit reached
+	ErrorCodeCustomRateLimit      = 613   // Calls to this API exceeded the rate limit
+	ErrorCodeBusinessRateLimitMin = 80000 // First Business Use Case rate limit code
+	ErrorCodeBusinessRateLimitMax = 80099 // Last Business Use Case rate limit code
+)
+
 // API Endpoints
 const (
 	BaseAPIURL = "https://graph.threads.net"
@@ -114,6 +160,23 @@ const (
 	ContainerErrUnknown                   = "UNKNOWN"
 )
 
+// Media specifications checked by InspectMedia, from the Threads media
+// requirements
+const (
+	MaxImageFileSize    = 8 << 20         // Maximum image file size (8 MB)
+	MaxVideoFileSize    = 1 << 30         // Maximum video file size (1 GB)
+	MaxMediaAspectRatio = 10.0            // Maximum width:height ratio (10:1)
+	MinMediaAspectRatio = 0.01            // Minimum width:height ratio (0.01:1)
+	MaxVideoWidth       = 1920            // Maximum video columns (horizontal pixels)
+	MaxVideoDuration    = 5 * time.Minute // Maximum video duration
+	MinVideoFrameRate   = 23              // Minimum video frame rate (FPS)
+	MaxVideoFrameRate   = 60              // Maximum video frame rate (FPS)
+	MaxVideoBitRate     = 100_000_000     // Maximum video bit rate (100 Mbps)
+	MaxAudioBitRate     = 128_000         // Maximum audio bit rate (128 kbps)
+	MaxAudioSampleRate  = 48_000          // Maximum audio sample rate (48 kHz)
+	MaxAudioChannels    = 2               // Mono or stereo audio only
+)
+
 // Error messages
 const (
 	ErrEmptyPostID      = "Post ID is required"
@@ -0,0 +1,162 @@
+package threads
+
+import (
+	"errors"
+	"strings"
+)
+
+// ContainerProcessingError is returned when the API reports that a media
+// container failed processing (Status ERROR). ErrorCode identifies the cause
+// as one of the ContainerErr* constants and Remediation describes how to fix
+// the media. Use errors.Is with the ErrContainer* sentinels to test for a
+// specific cause:
+//
+//	if errors.Is(err, threads.ErrContainerInvalidAspectRatio) {
+//	    // crop the video and try again
+//	}
+//
+// A ContainerProcessingError unwraps to its *ContainerError, so
+// IsContainerError also reports true for it.
+type ContainerProcessingError struct {
+	*ContainerError
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Sentinel errors for each container processing failure, for use with
+// errors.Is. They match any *ContainerProcessingError with the same
+// ErrorCode.
+var (
+	ErrContainerFailedDownloadingVideo    = newContainerProcessingSentinel(ContainerErrFailedDownloadingVideo)
+	ErrContainerFailedProcessingAudio     = newContainerProcessingSentinel(ContainerErrFailedProcessingAudio)
+	ErrContainerFailedProcessingVideo     = newContainerProcessingSentinel(ContainerErrFailedProcessingVideo)
+	ErrContainerInvalidAspectRatio        = newContainerProcessingSentinel(ContainerErrInvalidAspectRatio)
+	ErrContainerInvalidBitRate            = newContainerProcessingSentinel(ContainerErrInvalidBitRate)
+	ErrContainerInvalidDuration           = newContainerProcessingSentinel(ContainerErrInvalidDuration)
+	ErrContainerInvalidFrameRate          = newContainerProcessingSentinel(ContainerErrInvalidFrameRate)
+	ErrContainerInvalidAudioChannels      = newContainerProcessingSentinel(ContainerErrInvalidAudioChannels)
+	ErrContainerInvalidAudioChannelLayout = newContainerProcessingSentinel(ContainerErrInvalidAudioChannelLayout)
+	ErrContainerUnknown                   = newContainerProcessingSentinel(ContainerErrUnknown)
+)
+
+// containerRemediations describes how to fix media for each container error
+// code, based on the Threads media specifications.
+var containerRemediations = map[string]string{
+	ContainerErrFailedDownloadingVideo:    "Make sure the video URL is publicly reachable without authentication and stays available until processing finishes",
+	ContainerErrFailedProcessingAudio:     "Encode audio as AAC with a sample rate of at most 48 kHz",
+	ContainerErrFailedProcessingVideo:     "Use an MOV or MP4 file with HEVC or H.264 video, progressive scan, closed GOP and 4:2:0 chroma subsampling",
+	ContainerErrInvalidAspectRatio:        "Use an aspect ratio between 0.01:1 and 10:1; 9:16 is recommended for video",
+	ContainerErrInvalidBitRate:            "Keep video bit rate at or below 100 Mbps and audio bit rate at or below 128 kbps",
+	ContainerErrInvalidDuration:           "Keep video duration at or below 5 minutes",
+	ContainerErrInvalidFrameRate:          "Use a frame rate between 23 and 60 FPS",
+	ContainerErrInvalidAudioChannels:      "Use mono or stereo audio (1 or 2 channels)",
+	ContainerErrInvalidAudioChannelLayout: "Use a mono or stereo audio channel layout",
+	ContainerErrUnknown:                   "Check the media against the Threads media specifications and try again",
+}
+
+// NewContainerProcessingError creates a new error for a container in the
+// ERROR state. The status error message is mapped to a ContainerErr* code
+// and its remediation hint.
+func NewContainerProcessingError(containerID ContainerID, status *ContainerStatus) *ContainerProcessingError {
+	err := &ContainerProcessingError{ContainerError: NewContainerError(containerID, status)}
+	err.Remediation = ContainerErrorRemediation(err.ErrorCode)
+	return err
+}
+
+// ContainerErrorRemediation returns a human-readable hint for fixing media
+// that failed with code, one of the ContainerErr* constants.
+func ContainerErrorRemediation(code string) string {
+	if remediation, ok := containerRemediations[code]; ok {
+		return remediation
+	}
+	return containerRemediations[ContainerErrUnknown]
+}
+
+// Error implements the error interface, appending the remediation hint.
+func (e *ContainerProcessingError) Error() string {
+	if e.Remediation == "" {
+		return e.ContainerError.Error()
+	}
+	return e.ContainerError.Error() + " (" + e.Remediation + ")"
+}
+
+// Unwrap returns the underlying *ContainerError.
+func (e *ContainerProcessingError) Unwrap() error {
+	return e.ContainerError
+}
+
+// Is reports whether target is the ErrContainer* sentinel for e's ErrorCode.
+func (e *ContainerProcessingError) Is(target error) bool {
+	t, ok := target.(*ContainerProcessingError)
+	if !ok {
+		return false
+	}
+	if t.ContainerID == "" {
+		return t.ErrorCode == e.ErrorCode
+	}
+	return t == e
+}
+
+// IsContainerProcessingError checks if an error is a container processing error.
+// Returns true if the error is of type *ContainerProcessingError.
+func IsContainerProcessingError(err error) bool {
+	var containerProcessingError *ContainerProcessingError
+	ok := errors.As(err, &containerProcessingError)
+	return ok
+}
+
+// Err returns the error a container in this status causes when publishing:
+// a *ContainerProcessingError for ERROR, a *ContainerError for EXPIRED, and
+// nil otherwise.
+func (s *ContainerStatus) Err() error {
+	switch s.Status {
+	case ContainerStatusError:
+		return NewContainerProcessingError(ConvertToContainerID(s.ID), s)
+	case ContainerStatusExpired:
+		return NewContainerError(ConvertToContainerID(s.ID), s)
+	default:
+		return nil
+	}
+}
+
+// newContainerProcessingSentinel creates a sentinel error for code.
+func newContainerProcessingSentinel(code string) *ContainerProcessingError {
+	return &ContainerProcessingError{
+		ContainerError: &ContainerError{
+			BaseError: &BaseError{
+				Code:    400,
+				Message: "Container processing failed",
+				Type:    "container_error",
+				Details: code,
+			},
+			Status:    ContainerStatusError,
+			ErrorCode: code,
+		},
+		Remediation: ContainerErrorRemediation(code),
+	}
+}
+
+// containerErrorCode maps a container error_message to one of the
+// ContainerErr* constants, or ContainerErrUnknown if none matches. The
+// message may be the bare code or contain it.
+func containerErrorCode(message string) string {
+	normalized := strings.ToUpper(message)
+	// Accept the correct spelling of the API's INVALID_ASPEC_RATIO as well
+	normalized = strings.ReplaceAll(normalized, "INVALID_ASPECT_RATIO", ContainerErrInvalidAspectRatio)
+
+	for _, code := range []string{
+		ContainerErrFailedDownloadingVideo,
+		ContainerErrFailedProcessingAudio,
+		ContainerErrFailedProcessingVideo,
+		ContainerErrInvalidAspectRatio,
+		ContainerErrInvalidBitRate,
+		ContainerErrInvalidDuration,
+		ContainerErrInvalidFrameRate,
+		ContainerErrInvalidAudioChannelLayout,
+		ContainerErrInvalidAudioChannels,
+	} {
+		if strings.Contains(normalized, code) {
+			return code
+		}
+	}
+	return ContainerErrUnknown
+}
@@ -0,0 +1,55 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestContainerProcessingError_IsAndRemediation(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"ERROR","error_message":"INVALID_BIT_RATE"}`))
+
+	err := client.WaitForContainer(context.Background(), "c1")
+	if !errors.Is(err, ErrContainerInvalidBitRate) {
+		t.Fatalf("expected errors.Is to match ErrContainerInvalidBitRate, got %v", err)
+	}
+	if errors.Is(err, ErrContainerInvalidFrameRate) {
+		t.Error("expected errors.Is not to match a different code")
+	}
+	if !IsContainerProcessingError(err) || !IsContainerError(err) {
+		t.Errorf("expected both container error helpers to match, got %T", err)
+	}
+
+	var processingErr *ContainerProcessingError
+	if !errors.As(err, &processingErr) {
+		t.Fatalf("expected ContainerProcessingError, got %T", err)
+	}
+	if processingErr.Remediation != ContainerErrorRemediation(ContainerErrInvalidBitRate) || !strings.Contains(err.Error(), "100 Mbps") {
+		t.Errorf("expected the bit rate remediation in the error, got %q", err.Error())
+	}
+}
+
+func TestContainerProcessingError_WrappedByCreatePost(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"ERROR","error_message":"FAILED_DOWNLOADING_VIDEO"}`))
+
+	// The handler answers the create request with the same body, whose id
+	// becomes the container ID.
+	_, err := client.CreateVideoPost(context.Background(), &VideoPostContent{VideoURL: "https://example.com/v.mp4"})
+	if !errors.Is(err, ErrContainerFailedDownloadingVideo) {
+		t.Fatalf("expected wrapped ErrContainerFailedDownloadingVideo, got %v", err)
+	}
+}
+
+func TestContainerStatus_Err(t *testing.T) {
+	if err := (&ContainerStatus{ID: "c1", Status: ContainerStatusFinished}).Err(); err != nil {
+		t.Errorf("expected no error for FINISHED, got %v", err)
+	}
+	if err := (&ContainerStatus{ID: "c1", Status: ContainerStatusExpired}).Err(); !IsContainerError(err) || IsContainerProcessingError(err) {
+		t.Errorf("expected a plain ContainerError for EXPIRED, got %T", err)
+	}
+	err := (&ContainerStatus{ID: "c1", Status: ContainerStatusError, ErrorMessage: "mystery"}).Err()
+	if !errors.Is(err, ErrContainerUnknown) {
+		t.Errorf("expected ErrContainerUnknown for an unrecognized message, got %v", err)
+	}
+}
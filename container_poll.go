@@ -0,0 +1,178 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type containerPollConfigKey struct{}
+
+// ContainerPollConfig controls how the client polls a media container until
+// it is ready to be published. Text and image containers are usually ready
+// within seconds, while videos can take minutes to process, so long videos
+// typically need a backoff schedule and a longer Timeout:
+//
+//	config.ContainerPolling = &threads.ContainerPollConfig{
+//	    InitialInterval: 2 * time.Second,
+//	    MaxInterval:     15 * time.Second,
+//	    BackoffFactor:   1.5,
+//	    Timeout:         10 * time.Minute,
+//	}
+type ContainerPollConfig struct {
+	// InitialInterval is the delay between the first and second status
+	// checks (default: DefaultContainerPollInterval).
+	InitialInterval time.Duration
+
+	// MaxInterval caps the delay between status checks when BackoffFactor
+	// grows it (default: no cap).
+	MaxInterval time.Duration
+
+	// BackoffFactor multiplies the interval after each status check
+	// (default: 1, a fixed interval). Must be 0 or at least 1.
+	BackoffFactor float64
+
+	// Timeout is the overall time allowed for the container to become ready
+	// (default: no deadline other than MaxAttempts and the context).
+	Timeout time.Duration
+
+	// MaxAttempts limits the number of status checks. If both MaxAttempts
+	// and Timeout are 0, DefaultContainerPollMaxAttempts is used; if only
+	// Timeout is set, checks continue until it elapses.
+	MaxAttempts int
+
+	// OnStatus is called with every observed container status and the
+	// 1-based number of the check (optional). Use it to report progress.
+	// It runs on the polling goroutine, and may be called concurrently for
+	// the items of a carousel, so it should return quickly.
+	OnStatus func(status *ContainerStatus, attempt int)
+}
+
+// WithContainerPolling returns a context that makes calls issued with it
+// poll containers according to config instead of Config.ContainerPolling.
+// Zero fields fall back to the client's configuration; Timeout and
+// MaxAttempts are replaced together when either is set:
+//
+//	ctx := threads.WithContainerPolling(ctx, &threads.ContainerPollConfig{
+//	    Timeout:  5 * time.Minute,
+//	    OnStatus: func(s *threads.ContainerStatus, n int) { log.Println(s.Status) },
+//	})
+//	post, err := client.CreateVideoPost(ctx, content)
+func WithContainerPolling(ctx context.Context, config *ContainerPollConfig) context.Context {
+	return context.WithValue(ctx, containerPollConfigKey{}, config)
+}
+
+// validate checks the polling configuration for invalid values.
+func (p *ContainerPollConfig) validate() error {
+	if p.InitialInterval < 0 || p.MaxInterval < 0 || p.Timeout < 0 || p.MaxAttempts < 0 {
+		return fmt.Errorf("ContainerPolling durations and MaxAttempts must be non-negative")
+	}
+
+	if p.BackoffFactor != 0 && p.BackoffFactor < 1 {
+		return fmt.Errorf("ContainerPolling.BackoffFactor must be 0 or at least 1")
+	}
+
+	if p.MaxInterval > 0 && p.InitialInterval > p.MaxInterval {
+		return fmt.Errorf("ContainerPolling.InitialInterval cannot be greater than MaxInterval")
+	}
+
+	return nil
+}
+
+// containerPollConfigFor returns the polling policy for a call: the override
+// from ctx merged over Config.ContainerPolling, with defaults filled in.
+func (c *Client) containerPollConfigFor(ctx context.Context) ContainerPollConfig {
+	var merged ContainerPollConfig
+	if c.config.ContainerPolling != nil {
+		merged = *c.config.ContainerPolling
+	}
+
+	if override, _ := ctx.Value(containerPollConfigKey{}).(*ContainerPollConfig); override != nil {
+		if override.InitialInterval > 0 {
+			merged.InitialInterval = override.InitialInterval
+		}
+		if override.MaxInterval > 0 {
+			merged.MaxInterval = override.MaxInterval
+		}
+		if override.BackoffFactor > 0 {
+			merged.BackoffFactor = override.BackoffFactor
+		}
+		if override.Timeout > 0 || override.MaxAttempts > 0 {
+			merged.Timeout = override.Timeout
+			merged.MaxAttempts = override.MaxAttempts
+		}
+		if override.OnStatus != nil {
+			merged.OnStatus = override.OnStatus
+		}
+	}
+
+	if merged.InitialInterval <= 0 {
+		merged.InitialInterval = DefaultContainerPollInterval
+	}
+	if merged.BackoffFactor < 1 {
+		merged.BackoffFactor = 1
+	}
+	if merged.MaxAttempts <= 0 && merged.Timeout <= 0 {
+		merged.MaxAttempts = DefaultContainerPollMaxAttempts
+	}
+	return merged
+}
+
+// waitForContainerReady polls the container status until it's ready to be
+// published, following poll. It returns a *ContainerProcessingError if
+// processing failed, a *ContainerError if the container expired or the
+// policy gave up, an *AlreadyPublishedError if it was already published,
+// and ctx.Err() if ctx is done first.
+func (c *Client) waitForContainerReady(ctx context.Context, containerID ContainerID, poll ContainerPollConfig) error {
+	var deadline time.Time
+	if poll.Timeout > 0 {
+		deadline = time.Now().Add(poll.Timeout)
+	}
+	interval := poll.InitialInterval
+
+	for attempt := 1; ; attempt++ {
+		status, err := c.GetContainerStatus(ctx, containerID)
+		if err != nil {
+			return fmt.Errorf("failed to check container status: %w", err)
+		}
+		if poll.OnStatus != nil {
+			poll.OnStatus(status, attempt)
+		}
+
+		switch status.Status {
+		case ContainerStatusFinished:
+			return nil
+		case ContainerStatusPublished:
+			return NewAlreadyPublishedError(containerID)
+		case ContainerStatusError, ContainerStatusExpired:
+			return status.Err()
+		}
+
+		if poll.MaxAttempts > 0 && attempt >= poll.MaxAttempts {
+			return NewContainerError(containerID, status)
+		}
+
+		wait := interval
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return NewContainerError(containerID, status)
+			}
+			if wait > remaining {
+				// Check one last time right at the deadline
+				wait = remaining
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		interval = time.Duration(float64(interval) * poll.BackoffFactor)
+		if poll.MaxInterval > 0 && interval > poll.MaxInterval {
+			interval = poll.MaxInterval
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForContainer_PollingPolicyAndProgress(t *testing.T) {
+	var polls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&polls, 1) < 4 {
+			_, _ = w.Write([]byte(`{"id":"c1","status":"IN_PROGRESS"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"c1","status":"FINISHED"}`))
+	}
+	config := testClientConfig(t, http.HandlerFunc(handler))
+	config.ContainerPolling = &ContainerPollConfig{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := testClientWithConfig(t, config)
+
+	err := client.WaitForContainer(context.Background(), "c1")
+	var containerErr *ContainerError
+	if !errors.As(err, &containerErr) || containerErr.Status != ContainerStatusInProgress {
+		t.Fatalf("expected the Config policy to give up with a ContainerError, got %v", err)
+	}
+
+	var seen []string
+	var attempts []int
+	ctx := WithContainerPolling(context.Background(), &ContainerPollConfig{
+		MaxAttempts:   5,
+		BackoffFactor: 2,
+		MaxInterval:   4 * time.Millisecond,
+		OnStatus: func(status *ContainerStatus, attempt int) {
+			seen = append(seen, status.Status)
+			attempts = append(attempts, attempt)
+		},
+	})
+	atomic.StoreInt32(&polls, 0)
+	if err := client.WaitForContainer(ctx, "c1"); err != nil {
+		t.Fatalf("expected the per-call policy to allow more attempts, got %v", err)
+	}
+	if len(seen) != 4 || seen[3] != ContainerStatusFinished || attempts[3] != 4 {
+		t.Errorf("expected four progress reports ending in FINISHED, got %v %v", seen, attempts)
+	}
+}
+
+func TestWaitForContainer_Timeout(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"IN_PROGRESS"}`))
+	ctx := WithContainerPolling(context.Background(), &ContainerPollConfig{
+		InitialInterval: 20 * time.Millisecond,
+		Timeout:         50 * time.Millisecond,
+	})
+
+	start := time.Now()
+	err := client.WaitForContainer(ctx, "c1")
+	if !IsContainerError(err) {
+		t.Fatalf("expected ContainerError, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected polling to stop at the deadline, took %s", elapsed)
+	}
+}
+
+func TestWaitForContainer_ErrorCodes(t *testing.T) {
+	for message, code := range map[string]string{
+		"INVALID_ASPEC_RATIO":                     ContainerErrInvalidAspectRatio,
+		"Error: FAILED_PROCESSING_VIDEO":          ContainerErrFailedProcessingVideo,
+		"invalid_audio_channel_layout":            ContainerErrInvalidAudioChannelLayout,
+		"INVALID_AUDIO_CHANNELS":                  ContainerErrInvalidAudioChannels,
+		"something the library does not know":     ContainerErrUnknown,
+		"INVALID_ASPECT_RATIO (correct spelling)": ContainerErrInvalidAspectRatio,
+	} {
+		client := testClient(t, jsonHandler(200, `{"id":"c1","status":"ERROR","error_message":"`+message+`"}`))
+
+		err := client.WaitForContainer(context.Background(), "c1")
+		var containerErr *ContainerError
+		if !errors.As(err, &containerErr) {
+			t.Fatalf("%q: expected ContainerError, got %v", message, err)
+		}
+		if containerErr.ErrorCode != code || containerErr.ErrorMessage != message || containerErr.ContainerID != "c1" {
+			t.Errorf("%q: expected code %s, got %+v", message, code, containerErr)
+		}
+	}
+
+	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"EXPIRED"}`))
+	var containerErr *ContainerError
+	if err := client.WaitForContainer(context.Background(), "c1"); !errors.As(err, &containerErr) || containerErr.Status != ContainerStatusExpired {
+		t.Errorf("expected ContainerError for expired container, got %v", err)
+	}
+}
+
+func TestConfigValidate_ContainerPolling(t *testing.T) {
+	for _, poll := range []*ContainerPollConfig{
+		{BackoffFactor: 0.5},
+		{Timeout: -time.Second},
+		{InitialInterval: time.Minute, MaxInterval: time.Second},
+	} {
+		config := &Config{ClientID: "id", ClientSecret: "secret", RedirectURI: "https://example.com/cb", ContainerPolling: poll}
+		config.SetDefaults()
+		if err := config.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", poll)
+		}
+	}
+}
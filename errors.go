@@ -154,6 +154,106 @@ func NewAPIError(code int, message, details, requestID string) *APIError {
 	}
 }
 
+// QuotaExceededError is returned by the opt-in quota guard (see
+// Client.EnableQuotaGuard) when a call would exceed one of the publishing
+// quotas reported by GetPublishingLimits. No request is sent to the API.
+// ResetAt estimates when capacity may be available again.
+type QuotaExceededError struct {
+	*BaseError
+	Quota   EndpointClass `json:"quota"`
+	Limit   int           `json:"limit"`
+	Used    int           `json:"used"`
+	ResetAt time.Time     `json:"reset_at"`
+}
+
+// NewQuotaExceededError creates a new quota error for the given endpoint class.
+// The limit and used parameters are the quota total and the usage counted
+// against it, including calls made since the limits were last fetched.
+func NewQuotaExceededError(quota EndpointClass, limit, used int, resetAt time.Time) *QuotaExceededError {
+	return &QuotaExceededError{
+		BaseError: &BaseError{
+			Code:    429,
+			Message: fmt.Sprintf("%s quota exceeded", quota),
+			Type:    "quota_exceeded_error",
+			Details: fmt.Sprintf("%d of %d used; capacity expected by %s", used, limit, resetAt.Format(time.RFC3339)),
+		},
+		Quota:   quota,
+		Limit:   limit,
+		Used:    used,
+		ResetAt: resetAt,
+	}
+}
+
+// AlreadyPublishedError is returned when a publish request failed without a
+// definite answer, such as a timeout or 5xx, but the container's status shows
+// it was published anyway. The post exists but the API did not return its ID;
+// the request is not repeated so that no duplicate post is created.
+type AlreadyPublishedError struct {
+	*BaseError
+	ContainerID ContainerID `json:"container_id"`
+}
+
+// NewAlreadyPublishedError creates a new error for a container that was
+// published by an earlier, seemingly failed, attempt.
+func NewAlreadyPublishedError(containerID ContainerID) *AlreadyPublishedError {
+	return &AlreadyPublishedError{
+		BaseError: &BaseError{
+			Code:    409,
+			Message: "Container already published",
+			Type:    "already_published_error",
+			Details: fmt.Sprintf("Container %s was published by a previous attempt", containerID),
+		},
+		ContainerID: containerID,
+	}
+}
+
+// ContainerError is returned when a media container cannot be published
+// because processing failed (Status ERROR), it expired (Status EXPIRED), or
+// it did not finish before the polling policy gave up (Status is the last
+// observed status). For failed containers, ErrorCode is one of the
+// ContainerErr* constants, or ContainerErrUnknown when the API's message is
+// not recognized, and ErrorMessage holds the message as returned.
+type ContainerError struct {
+	*BaseError
+	ContainerID  ContainerID `json:"container_id"`
+	Status       string      `json:"status"`
+	ErrorCode    string      `json:"error_code,omitempty"`
+	ErrorMessage string      `json:"error_message,omitempty"`
+}
+
+// NewContainerError creates a new container error from an observed status.
+// The status error message is mapped to a ContainerErr* code for containers
+// in the ERROR state.
+func NewContainerError(containerID ContainerID, status *ContainerStatus) *ContainerError {
+	err := &ContainerError{
+		BaseError: &BaseError{
+			Code: 400,
+			Type: "container_error",
+		},
+		ContainerID:  containerID,
+		Status:       status.Status,
+		ErrorMessage: status.ErrorMessage,
+	}
+
+	switch status.Status {
+	case ContainerStatusError:
+		err.ErrorCode = containerErrorCode(status.ErrorMessage)
+		err.Message = "Container processing failed"
+		err.Details = status.ErrorMessage
+		if err.Details == "" {
+			err.Details = "container reported ERROR status without an error message"
+		}
+	case ContainerStatusExpired:
+		err.Message = "Container expired"
+		err.Details = fmt.Sprintf("Container %s expired before it could be published", containerID)
+	default:
+		err.Code = 408
+		err.Message = "Container not ready"
+		err.Details = fmt.Sprintf("timeout waiting for container %s, last status %s", containerID, status.Status)
+	}
+	return err
+}
+
 // extractBaseError returns the embedded BaseError from any of the typed error types.
 // Returns nil if the error is not one of the known types.
 func extractBaseError(err error) *BaseError {
@@ -168,6 +268,14 @@ func extractBaseError(err error) *BaseError {
 		return e.BaseError
 	case *APIError:
 		return e.BaseError
+	case *QuotaExceededError:
+		return e.BaseError
+	case *AlreadyPublishedError:
+		return e.BaseError
+	case *ContainerError:
+		return e.BaseError
+	case *ContainerProcessingError:
+		return e.BaseError
 	default:
 		return nil
 	}
@@ -227,6 +335,32 @@ func IsAPIError(err error) bool {
 	return ok
 }
 
+// IsQuotaExceededError checks if an error was returned by the quota guard.
+// The call was not sent; retry after the error's ResetAt.
+// Returns true if the error is of type *QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	var quotaExceededError *QuotaExceededError
+	ok := errors.As(err, &quotaExceededError)
+	return ok
+}
+
+// IsAlreadyPublishedError checks if a publish failed only in appearance:
+// the post was created, so the call must not be retried.
+// Returns true if the error is of type *AlreadyPublishedError.
+func IsAlreadyPublishedError(err error) bool {
+	var alreadyPublishedError *AlreadyPublishedError
+	ok := errors.As(err, &alreadyPublishedError)
+	return ok
+}
+
+// IsContainerError checks if an error is a container error.
+// Returns true if the error is of type *ContainerError.
+func IsContainerError(err error) bool {
+	var containerError *ContainerError
+	ok := errors.As(err, &containerError)
+	return ok
+}
+
 // IsTransientError checks if an error is marked as transient by the API.
 // Transient errors are temporary and the request can be retried.
 // Uses errors.As to support wrapped errors, consistent with other IsXxx helpers.
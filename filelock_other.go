@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package threads
+
+import "os"
+
+// lockFile is a no-op on platforms without advisory file locking; only
+// in-process serialization applies there.
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+// unlockFile is a no-op counterpart to lockFile.
+func unlockFile(f *os.File) error {
+	return nil
+}
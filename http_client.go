@@ -27,6 +27,15 @@ type HTTPClient struct {
 	rateLimiter atomic.Pointer[RateLimiter]
 	baseURL     string
 	userAgent   string
+	roundTrip   RoundTripFunc
+
+	// onInvalidToken is notified when a request fails because its access
+	// token was rejected (ErrorCodeInvalidOAuthToken).
+	onInvalidToken func(accessToken string, err error)
+
+	// onEndpointUse is notified after a successful call that counts against
+	// a publishing quota.
+	onEndpointUse func(class EndpointClass)
 }
 
 // RequestOptions holds options for HTTP requests
@@ -37,6 +46,7 @@ type RequestOptions struct {
 	Body        interface{}
 	Headers     map[string]string
 	Context     context.Context
+	RetryConfig *RetryConfig // Overrides the client's RetryConfig for this request (see WithRetryConfig)
 }
 
 // Response wraps HTTP response with additional metadata
@@ -45,6 +55,7 @@ type Response struct {
 	Body       []byte
 	RequestID  string
 	RateLimit  *RateLimitInfo
+	Usage      *APIUsage // Parsed X-App-Usage / X-Business-Use-Case-Usage, if present
 	Duration   time.Duration
 	StatusCode int
 }
@@ -55,12 +66,23 @@ type RateLimitInfo struct {
 	Remaining  int           `json:"remaining"`
 	Reset      time.Time     `json:"reset"`
 	RetryAfter time.Duration `json:"retry_after,omitempty"`
+	Usage      *APIUsage     `json:"usage,omitempty"`
+}
+
+// hasWindow reports whether the generic X-RateLimit-* headers were present.
+func (r *RateLimitInfo) hasWindow() bool {
+	return r.Limit != 0 || r.Remaining != 0 || !r.Reset.IsZero()
 }
 
-// NewHTTPClient creates a new HTTP client with the provided configuration
+// NewHTTPClient creates a new HTTP client with the provided configuration.
+// If config.HTTPClient is set it is used as the underlying client; otherwise
+// a new one is created with config.HTTPTimeout.
 func NewHTTPClient(config *Config, rateLimiter *RateLimiter) *HTTPClient {
-	httpClient := &http.Client{
-		Timeout: config.HTTPTimeout,
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: config.HTTPTimeout,
+		}
 	}
 
 	baseURL := config.BaseURL
@@ -80,6 +102,7 @@ func NewHTTPClient(config *Config, rateLimiter *RateLimiter) *HTTPClient {
 		baseURL:     baseURL,
 		userAgent:   userAgent,
 	}
+	h.roundTrip = chainMiddleware(h.executeRequest, config.Middleware)
 	h.rateLimiter.Store(rateLimiter)
 	return h
 }
@@ -105,9 +128,24 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 		}
 	}
 
+	// Pace quota-limited endpoints when proactive throttling is enabled
+	class := classifyEndpoint(opts)
+	if rl := h.getRateLimiter(); rl != nil && class != "" {
+		if err := rl.WaitForEndpoint(opts.Context, class); err != nil {
+			return nil, fmt.Errorf("rate limiter wait failed: %w", err)
+		}
+	}
+
+	roundTrip := h.roundTrip
+	if roundTrip == nil {
+		roundTrip = h.executeRequest
+	}
+
 	var lastErr error
-	maxRetries := h.retryConfig.MaxRetries
-	delay := h.retryConfig.InitialDelay
+	retry := h.retryConfigFor(opts)
+	maxRetries := retry.MaxRetries
+	backoff := retry.InitialDelay
+	var delay time.Duration
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
@@ -117,23 +155,44 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 				return nil, opts.Context.Err()
 			case <-time.After(delay):
 			}
-
-			// Exponential backoff
-			delay = time.Duration(float64(delay) * h.retryConfig.BackoffFactor)
-			if delay > h.retryConfig.MaxDelay {
-				delay = h.retryConfig.MaxDelay
-			}
 		}
 
-		resp, err := h.executeRequest(opts, accessToken)
+		resp, err := roundTrip(opts, accessToken)
+		if resp == nil && err == nil {
+			// A Middleware or custom RoundTripFunc must return one or the other
+			return nil, fmt.Errorf("middleware returned nil response")
+		}
 		if err != nil {
 			lastErr = err
 
+			// Throttled responses carry usage headers with the time to regain access.
+			if rl := h.getRateLimiter(); rl != nil && resp != nil && resp.Usage != nil {
+				rl.UpdateUsage(resp.Usage)
+			}
+
+			if h.onInvalidToken != nil && isInvalidTokenError(err) {
+				h.onInvalidToken(accessToken, err)
+			}
+
+			// A cancelled or expired caller context is never worth retrying;
+			// surface it immediately instead of entering another backoff.
+			if opts.Context.Err() != nil {
+				return nil, err
+			}
+
 			// Check if error is retry-able
 			if !h.isRetryableError(err) {
 				return nil, err
 			}
 
+			// Honor Retry-After from throttled responses, otherwise back off
+			// exponentially.
+			var ok bool
+			if delay, ok = retry.retryDelay(err, backoff); !ok {
+				return nil, err
+			}
+			backoff = retry.nextBackoff(backoff)
+
 			h.logRetry(attempt, maxRetries, err)
 			continue
 		}
@@ -143,9 +202,16 @@ func (h *HTTPClient) Do(opts *RequestOptions, accessToken string) (*Response, er
 			rl.UpdateFromHeaders(resp.RateLimit)
 		}
 
+		if h.onEndpointUse != nil && class != "" {
+			h.onEndpointUse(class)
+		}
+
 		return resp, nil
 	}
 
+	if maxRetries == 0 {
+		return nil, lastErr
+	}
 	return nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
 }
 
@@ -235,6 +301,9 @@ func (h *HTTPClient) executeRequest(opts *RequestOptions, accessToken string) (*
 		Duration:   time.Since(startTime),
 		RateLimit:  h.parseRateLimitHeaders(httpResp.Header),
 	}
+	if resp.RateLimit != nil {
+		resp.Usage = resp.RateLimit.Usage
+	}
 
 	// Log response
 	h.logResponse(resp)
@@ -272,11 +341,15 @@ func (h *HTTPClient) parseRateLimitHeaders(headers http.Header) *RateLimitInfo {
 	if retryAfterStr := headers.Get("Retry-After"); retryAfterStr != "" {
 		if retryAfter, err := strconv.Atoi(retryAfterStr); err == nil {
 			rateLimitInfo.RetryAfter = time.Duration(retryAfter) * time.Second
+		} else if retryAt, err := http.ParseTime(retryAfterStr); err == nil && time.Until(retryAt) > 0 {
+			rateLimitInfo.RetryAfter = time.Until(retryAt)
 		}
 	}
 
+	rateLimitInfo.Usage = parseUsageHeaders(headers)
+
 	// Return nil if no rate limit headers found
-	if rateLimitInfo.Limit == 0 && rateLimitInfo.Remaining == 0 && rateLimitInfo.Reset.IsZero() {
+	if !rateLimitInfo.hasWindow() && rateLimitInfo.RetryAfter == 0 && rateLimitInfo.Usage == nil {
 		return nil
 	}
 
@@ -317,19 +390,12 @@ func (h *HTTPClient) createErrorFromResponse(resp *Response) error {
 
 	// Create specific error types based on status code
 	var resultErr error
-	switch resp.StatusCode {
-	case 401, 403:
-		resultErr = NewAuthenticationError(errorCode, message, details)
-	case 429:
-		retryAfter := time.Duration(0)
+	switch {
+	case resp.StatusCode == 429 || isThrottlingErrorCode(errorCode):
+		retryAfter := retryAfterFromResponse(resp)
 		resetTime := time.Time{}
-		if resp.RateLimit != nil {
-			if resp.RateLimit.RetryAfter > 0 {
-				retryAfter = resp.RateLimit.RetryAfter
-			}
-			if !resp.RateLimit.Reset.IsZero() {
-				resetTime = resp.RateLimit.Reset
-			}
+		if resp.RateLimit != nil && !resp.RateLimit.Reset.IsZero() {
+			resetTime = resp.RateLimit.Reset
 		}
 
 		// Mark the rate limiter as rate limited by the API
@@ -342,7 +408,9 @@ func (h *HTTPClient) createErrorFromResponse(resp *Response) error {
 		}
 
 		resultErr = NewRateLimitError(errorCode, message, details, retryAfter)
-	case 400, 422:
+	case resp.StatusCode == 401 || resp.StatusCode == 403 || errorCode == ErrorCodeInvalidOAuthToken:
+		resultErr = NewAuthenticationError(errorCode, message, details)
+	case resp.StatusCode == 400 || resp.StatusCode == 422:
 		resultErr = NewValidationError(errorCode, message, details, "")
 	default:
 		resultErr = NewAPIError(errorCode, message, details, resp.RequestID)
@@ -353,6 +421,29 @@ func (h *HTTPClient) createErrorFromResponse(resp *Response) error {
 	return resultErr
 }
 
+// isThrottlingErrorCode reports whether a Graph API error code means the
+// request was throttled, whatever the HTTP status.
+func isThrottlingErrorCode(code int) bool {
+	switch code {
+	case ErrorCodeAppRateLimit, ErrorCodeUserRateLimit, ErrorCodePageRateLimit, ErrorCodeCustomRateLimit:
+		return true
+	}
+	return code >= ErrorCodeBusinessRateLimitMin && code <= ErrorCodeBusinessRateLimitMax
+}
+
+// retryAfterFromResponse returns how long a throttled response asks the
+// caller to wait: the Retry-After header, or else the estimated time to
+// regain access from the usage headers.
+func retryAfterFromResponse(resp *Response) time.Duration {
+	if resp.RateLimit != nil && resp.RateLimit.RetryAfter > 0 {
+		return resp.RateLimit.RetryAfter
+	}
+	if resp.Usage != nil {
+		return resp.Usage.RegainAccessIn()
+	}
+	return 0
+}
+
 // wrapNetworkError wraps network errors with appropriate error types.
 // The original error is preserved as the Cause, so errors.Is/errors.As
 // can inspect it (e.g., to detect context.Canceled).
@@ -385,7 +476,10 @@ func (h *HTTPClient) isRetryableError(err error) bool {
 	}
 
 	// Check base error for transient flag or 5xx HTTP status
-	baseErr := extractBaseError(err)
+	var baseErr *BaseError
+	for e := err; e != nil && baseErr == nil; e = errors.Unwrap(e) {
+		baseErr = extractBaseError(e)
+	}
 	if baseErr != nil {
 		if baseErr.IsTransient {
 			return true
@@ -513,37 +607,69 @@ func sanitizeURL(u *url.URL) string {
 	return clone.String()
 }
 
-// GET performs a GET request
+// GET performs a GET request using context.Background().
+// Prefer GETWithContext so that caller deadlines and cancellation apply.
 func (h *HTTPClient) GET(path string, queryParams url.Values, accessToken string) (*Response, error) {
+	return h.GETWithContext(context.Background(), path, queryParams, accessToken)
+}
+
+// GETWithContext performs a GET request bound to ctx. Cancelling ctx aborts
+// the in-flight request as well as any pending retry backoff.
+func (h *HTTPClient) GETWithContext(ctx context.Context, path string, queryParams url.Values, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
 		Method:      "GET",
 		Path:        path,
 		QueryParams: queryParams,
+		Context:     ctx,
 	}, accessToken)
 }
 
-// POST performs a POST request
+// POST performs a POST request using context.Background().
+// Prefer POSTWithContext so that caller deadlines and cancellation apply.
 func (h *HTTPClient) POST(path string, body interface{}, accessToken string) (*Response, error) {
+	return h.POSTWithContext(context.Background(), path, body, accessToken)
+}
+
+// POSTWithContext performs a POST request bound to ctx. Cancelling ctx aborts
+// the in-flight request as well as any pending retry backoff.
+func (h *HTTPClient) POSTWithContext(ctx context.Context, path string, body interface{}, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "POST",
-		Path:   path,
-		Body:   body,
+		Method:  "POST",
+		Path:    path,
+		Body:    body,
+		Context: ctx,
 	}, accessToken)
 }
 
-// PUT performs a PUT request
+// PUT performs a PUT request using context.Background().
+// Prefer PUTWithContext so that caller deadlines and cancellation apply.
 func (h *HTTPClient) PUT(path string, body interface{}, accessToken string) (*Response, error) {
+	return h.PUTWithContext(context.Background(), path, body, accessToken)
+}
+
+// PUTWithContext performs a PUT request bound to ctx. Cancelling ctx aborts
+// the in-flight request as well as any pending retry backoff.
+func (h *HTTPClient) PUTWithContext(ctx context.Context, path string, body interface{}, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "PUT",
-		Path:   path,
-		Body:   body,
+		Method:  "PUT",
+		Path:    path,
+		Body:    body,
+		Context: ctx,
 	}, accessToken)
 }
 
-// DELETE performs a DELETE request
+// DELETE performs a DELETE request using context.Background().
+// Prefer DELETEWithContext so that caller deadlines and cancellation apply.
 func (h *HTTPClient) DELETE(path string, accessToken string) (*Response, error) {
+	return h.DELETEWithContext(context.Background(), path, accessToken)
+}
+
+// DELETEWithContext performs a DELETE request bound to ctx. Cancelling ctx
+// aborts the in-flight request as well as any pending retry backoff.
+func (h *HTTPClient) DELETEWithContext(ctx context.Context, path string, accessToken string) (*Response, error) {
 	return h.Do(&RequestOptions{
-		Method: "DELETE",
-		Path:   path,
+		Method:  "DELETE",
+		Path:    path,
+		Context: ctx,
 	}, accessToken)
 }
@@ -3,6 +3,7 @@ package threads
 import (
 	"context"
 	"errors"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
@@ -96,6 +97,160 @@ func TestHTTPClient_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestHTTPClient_CancelledRequestIsNotRetried(t *testing.T) {
+	var attempts int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		<-r.Context().Done()
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      10 * time.Millisecond,
+		BackoffFactor: 1.0,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := httpClient.GETWithContext(ctx, "/slow", nil, "token")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected 1 attempt after cancellation, got %d", got)
+	}
+}
+
+func TestHTTPClient_CancelInterruptsRetryBackoff(t *testing.T) {
+	var attempts int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(503)
+		_, _ = w.Write([]byte(`{"error":{"message":"Unavailable","type":"OAuthException","code":2,"is_transient":true}}`))
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Second,
+		MaxDelay:      10 * time.Second,
+		BackoffFactor: 1.0,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := httpClient.POSTWithContext(ctx, "/test", url.Values{"k": {"v"}}, "token")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("backoff was not interrupted by cancellation, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("expected 1 attempt before cancellation, got %d", got)
+	}
+}
+
+func TestHTTPClient_WithContextVariantsPropagateContext(t *testing.T) {
+	type ctxKey struct{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{}`))
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries: 0, InitialDelay: time.Second, MaxDelay: time.Second, BackoffFactor: 1.0,
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "marker")
+	calls := map[string]func() (*Response, error){
+		"GET":    func() (*Response, error) { return httpClient.GETWithContext(ctx, "/t", nil, "token") },
+		"POST":   func() (*Response, error) { return httpClient.POSTWithContext(ctx, "/t", nil, "token") },
+		"PUT":    func() (*Response, error) { return httpClient.PUTWithContext(ctx, "/t", nil, "token") },
+		"DELETE": func() (*Response, error) { return httpClient.DELETEWithContext(ctx, "/t", "token") },
+	}
+	for method, call := range calls {
+		resp, err := call()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", method, err)
+		}
+		if got := resp.Request.Context().Value(ctxKey{}); got != "marker" {
+			t.Errorf("%s: request context not propagated, got %v", method, got)
+		}
+		if resp.Request.Method != method {
+			t.Errorf("expected method %s, got %s", method, resp.Request.Method)
+		}
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper for tests.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewHTTPClient_UsesSuppliedHTTPClient(t *testing.T) {
+	var seen *http.Request
+	custom := &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			seen = r
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true}`)),
+				Request:    r,
+			}, nil
+		}),
+	}
+
+	httpClient := NewHTTPClient(&Config{
+		HTTPTimeout: 5 * time.Second,
+		HTTPClient:  custom,
+		Logger:      &noopLogger{},
+		RetryConfig: &RetryConfig{MaxRetries: 0, InitialDelay: time.Second, MaxDelay: time.Second, BackoffFactor: 1.0},
+		BaseURL:     "https://graph.threads.invalid",
+	}, nil)
+
+	if httpClient.client != custom {
+		t.Fatal("expected supplied *http.Client to be used")
+	}
+	if custom.Timeout != 0 {
+		t.Errorf("HTTPTimeout should not be applied to a supplied client, got %v", custom.Timeout)
+	}
+
+	resp, err := httpClient.GET("/me", nil, "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if seen == nil || seen.URL.Host != "graph.threads.invalid" {
+		t.Fatalf("request did not go through the supplied transport: %v", seen)
+	}
+	if seen.Header.Get("Authorization") != "Bearer token" {
+		t.Errorf("expected Authorization header, got %q", seen.Header.Get("Authorization"))
+	}
+}
+
+func TestNewHTTPClient_DefaultAppliesTimeout(t *testing.T) {
+	httpClient := NewHTTPClient(&Config{
+		HTTPTimeout: 7 * time.Second,
+		RetryConfig: &RetryConfig{MaxRetries: 0, InitialDelay: time.Second, MaxDelay: time.Second, BackoffFactor: 1.0},
+	}, nil)
+
+	if httpClient.client == nil {
+		t.Fatal("expected a default *http.Client")
+	}
+	if httpClient.client.Timeout != 7*time.Second {
+		t.Errorf("expected timeout 7s, got %v", httpClient.client.Timeout)
+	}
+}
+
 func TestHTTPClient_ParseRateLimitHeaders(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("X-RateLimit-Limit", "100")
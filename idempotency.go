@@ -0,0 +1,291 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context that makes CreateTextPost, the other
+// Create*Post methods and the reply methods idempotent for key. The first call
+// records the container it creates and the post it publishes; a later call
+// with the same key, for example after a timeout or a crash, reuses the
+// recorded container instead of creating another one, and returns the
+// recorded post instead of publishing again.
+//
+// Keys are scoped to the authenticated account and kept in
+// Config.IdempotencyStore. Use a new key for each distinct post, such as a
+// job or message ID. Calls with the same key are serialized within a Client.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyRecord tracks the progress of an idempotent publish.
+type IdempotencyRecord struct {
+	Key         string      `json:"key"`
+	ContainerID ContainerID `json:"container_id,omitempty"` // Set once the container is created
+	PostID      PostID      `json:"post_id,omitempty"`      // Set once the container is published
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// IdempotencyStore persists idempotency records. Use a shared, durable
+// implementation to make retries safe across processes and restarts.
+// Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Get returns the record for key, or nil if there is none.
+	Get(key string) (*IdempotencyRecord, error)
+
+	// Put creates or replaces the record for record.Key.
+	Put(record *IdempotencyRecord) error
+}
+
+// MemoryIdempotencyStore keeps idempotency records in memory for a limited
+// time. It is the default IdempotencyStore.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore creates an in-memory store that forgets records
+// ttl after they were created. A ttl of 0 uses DefaultIdempotencyTTL.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &MemoryIdempotencyStore{ttl: ttl, records: make(map[string]IdempotencyRecord)}
+}
+
+// Get returns the record for key, or nil if there is none or it expired.
+func (s *MemoryIdempotencyStore) Get(key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	if time.Since(record.CreatedAt) > s.ttl {
+		delete(s.records, key)
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// Put stores a copy of record and drops expired records.
+func (s *MemoryIdempotencyStore) Put(record *IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, existing := range s.records {
+		if time.Since(existing.CreatedAt) > s.ttl {
+			delete(s.records, key)
+		}
+	}
+	s.records[record.Key] = *record
+	return nil
+}
+
+// keyedMutex serializes work per key.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock acquires the lock for key and returns a function that releases it.
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*keyedLock)
+	}
+	l := k.locks[key]
+	if l == nil {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.refs++
+	k.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		k.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// publishFlow describes the create, wait and publish steps of a post.
+type publishFlow struct {
+	container string // Used in errors, e.g. "text container"
+	post      string // Used in errors, e.g. "text post"
+	create    func(ctx context.Context) (string, error)
+	ready     func(ctx context.Context, containerID string) error
+}
+
+// createAndPublish runs flow, resuming from the idempotency record for the
+// key in ctx if there is one.
+func (c *Client) createAndPublish(ctx context.Context, flow publishFlow) (*Post, error) {
+	return c.publishIdempotent(ctx, func(record *IdempotencyRecord) (PostID, error) {
+		var containerID string
+		if record != nil && record.ContainerID.Valid() {
+			// A previous attempt created the container; make sure it was not
+			// published before the attempt failed.
+			containerID = record.ContainerID.String()
+			status, err := c.GetContainerStatus(ctx, record.ContainerID)
+			if err != nil {
+				return "", fmt.Errorf("failed to check recorded container: %w", err)
+			}
+			if status.Status == ContainerStatusPublished {
+				return "", NewAlreadyPublishedError(record.ContainerID)
+			}
+			if err := status.Err(); err != nil {
+				return "", err
+			}
+		} else {
+			id, err := flow.create(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to create %s: %w", flow.container, err)
+			}
+			containerID = id
+			if record != nil {
+				record.ContainerID = ConvertToContainerID(id)
+				c.saveIdempotencyRecord(record)
+			}
+		}
+
+		// Media hosted for the container is only kept after a failure if
+		// the container is recorded for a retry to resume
+		if err := flow.ready(ctx, containerID); err != nil {
+			if record == nil {
+				c.releaseHostedMedia(containerID)
+			}
+			return "", err
+		}
+
+		postID, err := c.publishContainerID(ctx, containerID)
+		if err != nil {
+			if record == nil {
+				c.releaseHostedMedia(containerID)
+			}
+			return "", fmt.Errorf("failed to publish %s: %w", flow.post, err)
+		}
+		return postID, nil
+	})
+}
+
+// publishIdempotent calls publish at most once successfully per idempotency
+// key in ctx and returns the published post. Without a key it simply calls
+// publish with a nil record.
+func (c *Client) publishIdempotent(ctx context.Context, publish func(record *IdempotencyRecord) (PostID, error)) (*Post, error) {
+	var record *IdempotencyRecord
+	if key, _ := ctx.Value(idempotencyKeyContextKey{}).(string); key != "" {
+		userID := c.getUserID()
+		if userID == "" {
+			return nil, NewAuthenticationError(401, "User ID not available", "Cannot determine user ID from token")
+		}
+		key = userID + "/" + key
+
+		unlock := c.idempotencyLocks.lock(key)
+		defer unlock()
+
+		var err error
+		if record, err = c.idempotencyStore.Get(key); err != nil {
+			return nil, fmt.Errorf("failed to load idempotency record: %w", err)
+		}
+		if record != nil && record.PostID.Valid() {
+			return c.GetPost(ctx, record.PostID)
+		}
+		if record == nil {
+			record = &IdempotencyRecord{Key: key, CreatedAt: time.Now()}
+		}
+	}
+
+	postID, err := publish(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		record.PostID = postID
+		c.saveIdempotencyRecord(record)
+	}
+
+	// Fetch the created post details
+	return c.GetPost(ctx, postID)
+}
+
+// saveIdempotencyRecord stores record. Failures are logged rather than
+// returned: the API call already succeeded and reporting an error would
+// invite the retry the record is meant to make safe.
+func (c *Client) saveIdempotencyRecord(record *IdempotencyRecord) {
+	if err := c.idempotencyStore.Put(record); err != nil && c.config.Logger != nil {
+		c.config.Logger.Warn("Failed to save idempotency record",
+			"key", record.Key,
+			"error", err.Error(),
+		)
+	}
+}
+
+// postPublishing sends a POST that publishes content. HTTP-level retries are
+// disabled because a request that timed out or failed with a 5xx may have
+// been applied anyway. Rate limited attempts were rejected and are retried;
+// other retryable failures are retried only if published is non-nil and
+// reports that nothing was published. If published reports that the content
+// was published, its error is returned.
+func (c *Client) postPublishing(ctx context.Context, path string, params url.Values, published func(ctx context.Context) error) (*Response, error) {
+	retry := c.httpClient.retryConfigFor(&RequestOptions{Context: ctx})
+	backoff := retry.InitialDelay
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.httpClient.Do(&RequestOptions{
+			Method:      "POST",
+			Path:        path,
+			Body:        params,
+			Context:     ctx,
+			RetryConfig: &RetryConfig{MaxRetries: 0},
+		}, c.getAccessTokenSafe())
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= retry.MaxRetries || ctx.Err() != nil || !c.httpClient.isRetryableError(err) {
+			return nil, err
+		}
+		ambiguous := !IsRateLimitError(err)
+		if ambiguous && published == nil {
+			return nil, err
+		}
+
+		delay, ok := retry.retryDelay(err, backoff)
+		if !ok {
+			return nil, err
+		}
+		backoff = retry.nextBackoff(backoff)
+		c.httpClient.logRetry(attempt, retry.MaxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if ambiguous {
+			if checkErr := published(ctx); checkErr != nil {
+				return nil, checkErr
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// publishServer fakes container creation, publishing and deletion. When
+// failPublishes > 0, that many publish requests are applied but answered
+// with a 500, as if the response was lost. When failCreate > 0, the create
+// request with that number is rejected with a 400.
+type publishServer struct {
+	mu            sync.Mutex
+	creates       int
+	publishes     int
+	failPublishes int
+	failCreate    int
+	published     map[string]bool
+	replyTo       map[string]string // Container ID to reply_to_id
+	deleted       []string
+}
+
+func (s *publishServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/threads"):
+		s.creates++
+		if s.creates == s.failCreate {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","code":100}}`))
+			return
+		}
+		id := "c" + strconv.Itoa(s.creates)
+		_ = r.ParseForm()
+		if s.replyTo == nil {
+			s.replyTo = make(map[string]string)
+		}
+		s.replyTo[id] = r.Form.Get("reply_to_id")
+		_, _ = w.Write([]byte(`{"id":"` + id + `"}`))
+	case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/threads_publish"):
+		s.publishes++
+		_ = r.ParseForm()
+		id := r.Form.Get("creation_id")
+		if s.published == nil {
+			s.published = make(map[string]bool)
+		}
+		s.published[id] = true
+		if s.failPublishes > 0 {
+			s.failPublishes--
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"message":"An unexpected error has occurred","code":2,"is_transient":true}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"p-` + id + `"}`))
+	case strings.HasPrefix(r.URL.Path, "/c"):
+		id := strings.TrimPrefix(r.URL.Path, "/")
+		status := ContainerStatusFinished
+		if s.published[id] {
+			status = ContainerStatusPublished
+		}
+		_, _ = w.Write([]byte(`{"id":"` + id + `","status":"` + status + `"}`))
+	case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/p-"):
+		s.deleted = append(s.deleted, strings.TrimPrefix(r.URL.Path, "/"))
+		_, _ = w.Write([]byte(`{"success":true}`))
+	case strings.HasPrefix(r.URL.Path, "/p-"):
+		_, _ = w.Write([]byte(`{"id":"` + strings.TrimPrefix(r.URL.Path, "/") + `","text":"hello","owner":{"id":"12345"}}`))
+	case r.Method == "GET" && r.URL.Path == "/12345":
+		_, _ = w.Write([]byte(`{"id":"12345","username":"tester"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *publishServer) counts() (creates, publishes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.creates, s.publishes
+}
+
+func publishTestClient(t *testing.T, srv *publishServer) *Client {
+	t.Helper()
+	config := testClientConfig(t, srv)
+	config.RetryConfig = &RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, BackoffFactor: 2}
+	return testClientWithConfig(t, config)
+}
+
+func TestIdempotencyKey_ReturnsRecordedPost(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	ctx := WithIdempotencyKey(context.Background(), "job-1")
+
+	first, err := client.CreateTextPost(ctx, &TextPostContent{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.CreateTextPost(ctx, &TextPostContent{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("expected the recorded post %s, got %s", first.ID, second.ID)
+	}
+	if creates, publishes := srv.counts(); creates != 1 || publishes != 1 {
+		t.Errorf("expected one container and one publish, got %d and %d", creates, publishes)
+	}
+
+	if _, err := client.CreateTextPost(WithIdempotencyKey(context.Background(), "job-2"), &TextPostContent{Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if creates, _ := srv.counts(); creates != 2 {
+		t.Errorf("expected a new key to create a new container, got %d creates", creates)
+	}
+}
+
+func TestPublish_AmbiguousFailureIsNotRepublished(t *testing.T) {
+	srv := &publishServer{failPublishes: 1}
+	client := publishTestClient(t, srv)
+
+	_, err := client.CreateTextPost(context.Background(), &TextPostContent{Text: "hello"})
+	if !IsAlreadyPublishedError(err) {
+		t.Fatalf("expected AlreadyPublishedError, got %v", err)
+	}
+	if _, publishes := srv.counts(); publishes != 1 {
+		t.Errorf("expected the publish not to be repeated, got %d publishes", publishes)
+	}
+}
+
+func TestIdempotencyKey_ResumesRecordedContainer(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	store := client.idempotencyStore
+
+	// Simulate a previous attempt that created a container and then died.
+	if err := store.Put(&IdempotencyRecord{Key: "12345/job-1", ContainerID: "c9", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	post, err := client.CreateTextPost(WithIdempotencyKey(context.Background(), "job-1"), &TextPostContent{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.ID != "p-c9" {
+		t.Errorf("expected the recorded container to be published, got post %s", post.ID)
+	}
+	if creates, _ := srv.counts(); creates != 0 {
+		t.Errorf("expected no new container, got %d creates", creates)
+	}
+
+	record, _ := store.Get("12345/job-1")
+	if record == nil || record.PostID != "p-c9" {
+		t.Errorf("expected the post ID to be recorded, got %+v", record)
+	}
+}
+
+func TestMemoryIdempotencyStore_Expires(t *testing.T) {
+	store := NewMemoryIdempotencyStore(time.Minute)
+	_ = store.Put(&IdempotencyRecord{Key: "old", CreatedAt: time.Now().Add(-2 * time.Minute)})
+	_ = store.Put(&IdempotencyRecord{Key: "new", CreatedAt: time.Now()})
+
+	if record, _ := store.Get("old"); record != nil {
+		t.Errorf("expected expired record to be dropped, got %+v", record)
+	}
+	if record, _ := store.Get("new"); record == nil {
+		t.Error("expected fresh record to be kept")
+	}
+}
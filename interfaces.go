@@ -75,6 +75,15 @@ type PostCreator interface {
 	// CreateCarouselPost creates a carousel post with multiple media items
 	CreateCarouselPost(ctx context.Context, content *CarouselPostContent) (*Post, error)
 
+	// CreateThreadChain publishes parts as a thread, each part replying to the previous one
+	CreateThreadChain(ctx context.Context, parts []PostDraft, opts *ThreadChainOptions) (*ThreadChainResult, error)
+
+	// ResumeThreadChain publishes the parts of a chain that were not published yet
+	ResumeThreadChain(ctx context.Context, result *ThreadChainResult, parts []PostDraft, opts *ThreadChainOptions) (*ThreadChainResult, error)
+
+	// RollbackThreadChain deletes the published parts of a chain
+	RollbackThreadChain(ctx context.Context, result *ThreadChainResult) error
+
 	// CreateQuotePost creates a quote post using any supported content type
 	CreateQuotePost(ctx context.Context, content interface{}, quotedPostID string) (*Post, error)
 
@@ -86,6 +95,24 @@ type PostCreator interface {
 
 	// GetContainerStatus retrieves the status of a media container
 	GetContainerStatus(ctx context.Context, containerID ContainerID) (*ContainerStatus, error)
+
+	// PrepareTextPost creates a text post container without publishing it
+	PrepareTextPost(ctx context.Context, content *TextPostContent) (ContainerID, error)
+
+	// PrepareImagePost creates an image post container without publishing it
+	PrepareImagePost(ctx context.Context, content *ImagePostContent) (ContainerID, error)
+
+	// PrepareVideoPost creates a video post container without publishing it
+	PrepareVideoPost(ctx context.Context, content *VideoPostContent) (ContainerID, error)
+
+	// PrepareCarouselPost creates a carousel post container without publishing it
+	PrepareCarouselPost(ctx context.Context, content *CarouselPostContent) (ContainerID, error)
+
+	// WaitForContainer waits until a container is ready to be published
+	WaitForContainer(ctx context.Context, containerID ContainerID) error
+
+	// PublishContainer publishes a prepared container
+	PublishContainer(ctx context.Context, containerID ContainerID) (*Post, error)
 }
 
 // PostReader handles post retrieval operations
@@ -168,6 +195,9 @@ type ReplyManager interface {
 	// ReplyToPost creates a reply to a specific post
 	ReplyToPost(ctx context.Context, postID PostID, content *PostContent) (*Post, error)
 
+	// ReplyWithDraft creates a reply from text, image, video or carousel content
+	ReplyWithDraft(ctx context.Context, draft PostDraft) (*Post, error)
+
 	// GetReplies retrieves replies to a post
 	GetReplies(ctx context.Context, postID PostID, opts *RepliesOptions) (*RepliesResponse, error)
 
@@ -13,6 +13,11 @@ func (c *Client) SearchLocations(ctx context.Context, query string, latitude, lo
 		return nil, err
 	}
 
+	// Fail fast if the quota guard knows the location search quota is used up
+	if err := c.checkQuota(ctx, EndpointLocationSearch); err != nil {
+		return nil, err
+	}
+
 	// Build query parameters
 	params := url.Values{
 		"fields": {LocationFields}, // Include all location fields for search results
@@ -41,7 +46,7 @@ func (c *Client) SearchLocations(ctx context.Context, query string, latitude, lo
 	}
 
 	// Make API call
-	resp, err := c.httpClient.GET("/location_search", params, c.getAccessTokenSafe())
+	resp, err := c.httpClient.GETWithContext(ctx, "/location_search", params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +82,7 @@ func (c *Client) GetLocation(ctx context.Context, locationID LocationID) (*Locat
 
 	// Make API call
 	path := fmt.Sprintf("/%s", locationID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	resp, err := c.httpClient.GETWithContext(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get location: %w", err)
 	}
@@ -0,0 +1,245 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MediaFile is local media to be made public by a MediaHost.
+type MediaFile struct {
+	// Name is a file name for the media, such as the base name of its path.
+	// Its extension is used to tell the content type; it may be empty.
+	Name string
+
+	// MediaType is MediaTypeImage or MediaTypeVideo.
+	MediaType string
+
+	// Size is the size of the content in bytes, or -1 if unknown.
+	Size int64
+
+	// Reader provides the content. It is only valid during Upload.
+	Reader io.Reader
+}
+
+// MediaHost makes local media reachable at a public URL, because the
+// Threads API only accepts media by URL. Set Config.MediaHost to post
+// content with ImagePath, ImageReader, VideoPath, VideoReader or local
+// CarouselItems.
+//
+// Upload must read the file's content before returning. The returned URL
+// must be fetchable by the Threads servers until cleanup is called, which
+// happens once the container created from the URL leaves the IN_PROGRESS
+// state, or right away if the container could not be created. cleanup may
+// be nil. Implementations must be safe for concurrent use. TempMediaServer
+// is a reference implementation.
+type MediaHost interface {
+	Upload(ctx context.Context, file *MediaFile) (publicURL string, cleanup func(), err error)
+}
+
+// hostedUpload is the cleanup of media hosted for a container. Media is kept
+// until the container is seen to finish processing, but no longer than
+// containerLifetime, after which it cannot be needed.
+type hostedUpload struct {
+	cleanup func()
+	expires time.Time
+}
+
+// hasLocalMedia reports whether a media source is a local path or reader.
+func hasLocalMedia(path string, r io.Reader) bool {
+	return path != "" || r != nil
+}
+
+// validateMediaSource checks that exactly one of mediaURL, path and r is
+// set, and that a MediaHost is configured for local media.
+func (c *Client) validateMediaSource(mediaURL, path string, r io.Reader, mediaType string) error {
+	sources := 0
+	for _, set := range []bool{mediaURL != "", path != "", r != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return NewValidationError(400, "Multiple media sources",
+			fmt.Sprintf("Set only one of the %s URL, path and reader", mediaType), "media_url")
+	}
+
+	if !hasLocalMedia(path, r) {
+		return NewValidator().ValidateMediaURL(mediaURL, mediaType)
+	}
+	if c.config.MediaHost == nil {
+		return NewValidationError(400, "Media host is required",
+			fmt.Sprintf("Set Config.MediaHost to post a local %s file", mediaType), "media_host")
+	}
+	return nil
+}
+
+// hostMedia returns the public URL of media given by URL, local path or
+// reader, uploading local media to the MediaHost. The returned cleanup
+// function is never nil.
+func (c *Client) hostMedia(ctx context.Context, mediaType, mediaURL, path string, r io.Reader) (string, func(), error) {
+	noop := func() {}
+	if !hasLocalMedia(path, r) {
+		return mediaURL, noop, nil
+	}
+	if c.config.MediaHost == nil {
+		return "", noop, NewValidationError(400, "Media host is required",
+			"Set Config.MediaHost to post local media files", "media_host")
+	}
+
+	file := &MediaFile{MediaType: mediaType, Size: -1, Reader: r}
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return "", noop, fmt.Errorf("failed to open media file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+
+		if stat, err := f.Stat(); err == nil {
+			file.Size = stat.Size()
+		}
+		file.Name = filepath.Base(path)
+		file.Reader = f
+	}
+
+	publicURL, cleanup, err := c.config.MediaHost.Upload(ctx, file)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to upload %s: %w", strings.ToLower(mediaType), err)
+	}
+	if cleanup == nil {
+		cleanup = noop
+	}
+	return publicURL, cleanup, nil
+}
+
+// createHostedContainer hosts local media, creates a container from its URL
+// with create and arranges for the hosted media to be cleaned up once the
+// container is processed.
+func (c *Client) createHostedContainer(ctx context.Context, mediaType, mediaURL, path string, r io.Reader, create func(mediaURL string) (string, error)) (string, error) {
+	publicURL, cleanup, err := c.hostMedia(ctx, mediaType, mediaURL, path, r)
+	if err != nil {
+		return "", err
+	}
+
+	containerID, err := create(publicURL)
+	if err != nil {
+		cleanup()
+		return "", err
+	}
+	if hasLocalMedia(path, r) {
+		c.trackHostedMedia(containerID, cleanup)
+	}
+	return containerID, nil
+}
+
+// trackHostedMedia records cleanup to run when the container's status is
+// next seen to be other than IN_PROGRESS, or once the container has expired.
+// Media hosted for containers that have expired is cleaned up.
+func (c *Client) trackHostedMedia(containerID string, cleanup func()) {
+	now := time.Now()
+	var expired []func()
+
+	c.hostedMu.Lock()
+	if c.hostedMedia == nil {
+		c.hostedMedia = make(map[string]hostedUpload)
+	}
+	for id, upload := range c.hostedMedia {
+		if now.After(upload.expires) {
+			expired = append(expired, upload.cleanup)
+			delete(c.hostedMedia, id)
+		}
+	}
+	c.hostedMedia[containerID] = hostedUpload{cleanup: cleanup, expires: now.Add(containerLifetime)}
+	c.hostedMu.Unlock()
+
+	for _, cleanup := range expired {
+		cleanup()
+	}
+}
+
+// releaseHostedMedia runs the cleanup recorded for each container, if any.
+func (c *Client) releaseHostedMedia(containerIDs ...string) {
+	var cleanups []func()
+	c.hostedMu.Lock()
+	for _, id := range containerIDs {
+		if upload, ok := c.hostedMedia[id]; ok {
+			cleanups = append(cleanups, upload.cleanup)
+			delete(c.hostedMedia, id)
+		}
+	}
+	c.hostedMu.Unlock()
+
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
+}
+
+// createCarouselItems creates containers for the carousel's Items and
+// returns their IDs after the IDs in Children.
+func (c *Client) createCarouselItems(ctx context.Context, content *CarouselPostContent) ([]string, error) {
+	children := append([]string(nil), content.Children...)
+	for i, item := range content.Items {
+		mediaType := strings.ToUpper(item.MediaType)
+		id, err := c.createHostedContainer(ctx, mediaType, item.URL, item.Path, item.Reader, func(mediaURL string) (string, error) {
+			id, err := c.CreateMediaContainer(ctx, mediaType, mediaURL, item.AltText)
+			return id.String(), err
+		})
+		if err != nil {
+			// The items created so far are abandoned
+			c.releaseHostedMedia(children[len(content.Children):]...)
+			return nil, fmt.Errorf("failed to create carousel item %d: %w", i+1, err)
+		}
+		children = append(children, id)
+	}
+	return children, nil
+}
+
+// createCarouselPostContainer creates the containers for the carousel's
+// Items, waits for all item containers to finish processing and creates
+// the carousel container.
+func (c *Client) createCarouselPostContainer(ctx context.Context, content *CarouselPostContent) (string, error) {
+	children, err := c.createCarouselItems(ctx, content)
+	if err != nil {
+		return "", err
+	}
+
+	// The item containers are abandoned if the carousel is not created
+	items := children[len(content.Children):]
+
+	// The Threads API requires child containers to be in FINISHED status
+	if err := c.waitForCarouselChildren(ctx, children); err != nil {
+		c.releaseHostedMedia(items...)
+		return "", err
+	}
+
+	carousel := *content
+	carousel.Children = children
+	id, err := c.createCarouselContainer(ctx, &carousel)
+	if err != nil {
+		c.releaseHostedMedia(items...)
+		return "", err
+	}
+	return id, nil
+}
+
+// hasMediaReader reports whether content provides media through a reader,
+// which cannot be stored for later use.
+func hasMediaReader(content interface{}) bool {
+	switch v := content.(type) {
+	case *ImagePostContent:
+		return v.ImageReader != nil
+	case *VideoPostContent:
+		return v.VideoReader != nil
+	case *CarouselPostContent:
+		for _, item := range v.Items {
+			if item.Reader != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
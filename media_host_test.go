@@ -0,0 +1,230 @@
+package threads
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMediaHost records uploads and how often each upload was cleaned up.
+type fakeMediaHost struct {
+	mu       sync.Mutex
+	uploads  []string // Names and content, as "name:content"
+	cleanups map[string]int
+}
+
+func (h *fakeMediaHost) Upload(_ context.Context, file *MediaFile) (string, func(), error) {
+	data, err := io.ReadAll(file.Reader)
+	if err != nil {
+		return "", nil, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.uploads = append(h.uploads, file.Name+":"+string(data))
+	publicURL := "https://media.example.com/" + file.Name
+	return publicURL, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if h.cleanups == nil {
+			h.cleanups = make(map[string]int)
+		}
+		h.cleanups[publicURL]++
+	}, nil
+}
+
+// mediaURLRecorder records the media URLs sent in container create requests.
+type mediaURLRecorder struct {
+	next http.Handler
+	mu   sync.Mutex
+	urls []string
+}
+
+func (m *mediaURLRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/threads") {
+		_ = r.ParseForm()
+		m.mu.Lock()
+		if u := r.Form.Get("image_url") + r.Form.Get("video_url"); u != "" {
+			m.urls = append(m.urls, u)
+		}
+		m.mu.Unlock()
+	}
+	m.next.ServeHTTP(w, r)
+}
+
+func mediaHostTestClient(t *testing.T, host MediaHost) (*Client, *mediaURLRecorder) {
+	t.Helper()
+	rec := &mediaURLRecorder{next: &publishServer{}}
+	config := testClientConfig(t, rec)
+	config.MediaHost = host
+	return testClientWithConfig(t, config), rec
+}
+
+func TestCreateImagePost_UploadsLocalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(path, []byte("jpeg data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	host := &fakeMediaHost{}
+	client, rec := mediaHostTestClient(t, host)
+
+	if _, err := client.CreateImagePost(context.Background(), &ImagePostContent{ImagePath: path}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(host.uploads) != 1 || host.uploads[0] != "photo.jpg:jpeg data" {
+		t.Errorf("uploads = %v", host.uploads)
+	}
+	if len(rec.urls) != 1 || rec.urls[0] != "https://media.example.com/photo.jpg" {
+		t.Errorf("image_url = %v", rec.urls)
+	}
+	// Released when the container was seen to be FINISHED, and only once
+	if n := host.cleanups["https://media.example.com/photo.jpg"]; n != 1 {
+		t.Errorf("cleanup called %d times, want 1", n)
+	}
+}
+
+func TestCreateCarouselPost_CreatesItemContainers(t *testing.T) {
+	host := &fakeMediaHost{}
+	client, rec := mediaHostTestClient(t, host)
+
+	_, err := client.CreateCarouselPost(context.Background(), &CarouselPostContent{
+		Items: []CarouselItem{
+			{MediaType: MediaTypeImage, Reader: strings.NewReader("local")},
+			{MediaType: MediaTypeVideo, URL: "https://example.com/clip.mp4"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"https://media.example.com/", "https://example.com/clip.mp4"}
+	if len(rec.urls) != len(want) || rec.urls[0] != want[0] || rec.urls[1] != want[1] {
+		t.Errorf("media URLs = %v, want %v", rec.urls, want)
+	}
+	if len(host.uploads) != 1 || host.cleanups["https://media.example.com/"] != 1 {
+		t.Errorf("uploads = %v, cleanups = %v", host.uploads, host.cleanups)
+	}
+}
+
+func TestCreateImagePost_ReleasesMediaWhenNotReady(t *testing.T) {
+	srv := &publishServer{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"c1","status":"IN_PROGRESS"}`))
+			return
+		}
+		srv.ServeHTTP(w, r)
+	})
+	host := &fakeMediaHost{}
+	config := testClientConfig(t, handler)
+	config.MediaHost = host
+	config.ContainerPolling = &ContainerPollConfig{MaxAttempts: 2, InitialInterval: time.Millisecond}
+	client := testClientWithConfig(t, config)
+
+	_, err := client.CreateImagePost(context.Background(), &ImagePostContent{ImageReader: strings.NewReader("jpeg data")})
+	if err == nil {
+		t.Fatal("expected the container to never become ready")
+	}
+	if n := host.cleanups["https://media.example.com/"]; n != 1 {
+		t.Errorf("cleanup called %d times, want 1", n)
+	}
+	if len(client.hostedMedia) != 0 {
+		t.Errorf("%d hosted media entries left on the client", len(client.hostedMedia))
+	}
+}
+
+func TestTrackHostedMedia_CleansUpExpired(t *testing.T) {
+	client := newBareClient(t)
+	var expired, current int
+	client.trackHostedMedia("c1", func() { expired++ })
+	client.hostedMedia["c1"] = hostedUpload{cleanup: client.hostedMedia["c1"].cleanup, expires: time.Now().Add(-time.Second)}
+
+	client.trackHostedMedia("c2", func() { current++ })
+	if expired != 1 || current != 0 {
+		t.Errorf("cleanups = %d expired, %d current; want 1, 0", expired, current)
+	}
+	if _, ok := client.hostedMedia["c1"]; ok {
+		t.Error("expired entry still tracked")
+	}
+
+	client.releaseHostedMedia("c1", "c2")
+	if expired != 1 || current != 1 {
+		t.Errorf("cleanups = %d expired, %d current; want 1, 1", expired, current)
+	}
+}
+
+func TestValidateMediaSource(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{}`))
+
+	err := client.ValidateImagePostContent(&ImagePostContent{ImagePath: "photo.jpg"})
+	if !IsValidationError(err) || !strings.Contains(err.Error(), "Media host is required") {
+		t.Errorf("local image without host: %v", err)
+	}
+
+	client.config.MediaHost = &fakeMediaHost{}
+	err = client.ValidateVideoPostContent(&VideoPostContent{VideoURL: "https://example.com/a.mp4", VideoPath: "a.mp4"})
+	if !IsValidationError(err) || !strings.Contains(err.Error(), "Multiple media sources") {
+		t.Errorf("URL and path: %v", err)
+	}
+
+	if err := client.ValidateImagePostContent(&ImagePostContent{ImageReader: strings.NewReader("x")}); err != nil {
+		t.Errorf("local image with host: %v", err)
+	}
+}
+
+func TestTempMediaServer_ServesUntilCleanup(t *testing.T) {
+	srv, err := NewTempMediaServer(TempMediaServerOptions{Addr: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = srv.Close() }()
+
+	fileURL, cleanup, err := srv.Upload(context.Background(), &MediaFile{Name: "photo.png", Reader: strings.NewReader("png data")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(fileURL, srv.URL()+"/") || !strings.HasSuffix(fileURL, "/photo.png") {
+		t.Fatalf("URL = %q", fileURL)
+	}
+
+	get := func(u string) (int, string) {
+		t.Helper()
+		resp, err := http.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if status, body := get(fileURL); status != http.StatusOK || body != "png data" {
+		t.Errorf("GET = %d %q", status, body)
+	}
+	if status, _ := get(srv.URL() + "/0000/photo.png"); status != http.StatusNotFound {
+		t.Errorf("GET with wrong token = %d, want 404", status)
+	}
+
+	cleanup()
+	cleanup()
+	if status, _ := get(fileURL); status != http.StatusNotFound {
+		t.Errorf("GET after cleanup = %d, want 404", status)
+	}
+	if entries, _ := os.ReadDir(srv.opts.Dir); len(entries) != 0 {
+		t.Errorf("%d files left after cleanup", len(entries))
+	}
+
+	if err := srv.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(srv.opts.Dir); !os.IsNotExist(err) {
+		t.Errorf("media directory not removed: %v", err)
+	}
+}
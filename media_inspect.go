@@ -0,0 +1,239 @@
+package threads
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"time"
+)
+
+// Media formats reported by InspectMedia
+const (
+	MediaFormatJPEG = "jpeg"
+	MediaFormatPNG  = "png"
+	MediaFormatGIF  = "gif"
+	MediaFormatWebP = "webp"
+	MediaFormatHEIF = "heif"
+	MediaFormatMP4  = "mp4"
+	MediaFormatMOV  = "mov"
+)
+
+// MediaInfo describes a local image or video file, as read from its headers
+// by InspectMedia. Fields that do not apply to the media type, or that the
+// file does not record, are zero.
+type MediaInfo struct {
+	MediaType string // MediaTypeImage or MediaTypeVideo
+	Format    string // One of the MediaFormat* constants
+	Size      int64  // File size in bytes
+	Width     int    // Display width in pixels, after rotation
+	Height    int    // Display height in pixels, after rotation
+
+	// Video only
+	Duration   time.Duration
+	FrameRate  float64 // Average frames per second
+	BitRate    int64   // Average video bit rate in bits per second
+	VideoCodec string  // Sample entry type, e.g. "avc1" or "hvc1"
+	FastStart  bool    // The moov atom precedes the media data
+
+	// Audio track of a video, if any
+	AudioCodec      string // Sample entry type, e.g. "mp4a"
+	AudioChannels   int
+	AudioSampleRate int   // Samples per second
+	AudioBitRate    int64 // Average audio bit rate in bits per second
+}
+
+// MediaViolation is a way in which media does not meet the Threads media
+// specifications. Code is the ContainerErr* code the API is expected to
+// report when processing the media, so errors.Is matches a violation against
+// the corresponding ErrContainer* sentinel:
+//
+//	if errors.Is(info.Validate(), threads.ErrContainerInvalidDuration) {
+//	    // trim the video
+//	}
+type MediaViolation struct {
+	Code    string `json:"code"`    // One of the ContainerErr* constants
+	Field   string `json:"field"`   // The MediaInfo property at fault, e.g. "duration"
+	Message string `json:"message"` // What is wrong and what the limit is
+}
+
+// Error implements the error interface.
+func (v *MediaViolation) Error() string {
+	return fmt.Sprintf("media violates Threads specifications: %s (%s)", v.Message, v.Code)
+}
+
+// Is reports whether target is the ErrContainer* sentinel for v's Code.
+func (v *MediaViolation) Is(target error) bool {
+	t, ok := target.(*ContainerProcessingError)
+	return ok && t.ContainerID == "" && t.ErrorCode == v.Code
+}
+
+// InspectMediaFile inspects the image or video file at path; see InspectMedia.
+func InspectMediaFile(path string) (*MediaInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open media file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat media file: %w", err)
+	}
+	return InspectMedia(f, stat.Size())
+}
+
+// InspectMedia reads the headers of the size-byte image or video in r and
+// describes it. It recognizes JPEG and PNG images, reports GIF, WebP and
+// HEIF images by format only, and reads MP4 and MOV videos from their
+// container atoms without decoding any media data. Use Violations or
+// Validate to check the result against the Threads media specifications
+// before uploading the file.
+//
+// It returns a *ValidationError if the format is not recognized or the file
+// is malformed.
+func InspectMedia(r io.ReaderAt, size int64) (*MediaInfo, error) {
+	head := make([]byte, 16)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read media file: %w", err)
+	}
+	head = head[:n]
+
+	info := &MediaInfo{MediaType: MediaTypeImage, Size: size}
+	switch {
+	case bytes.HasPrefix(head, []byte("\xff\xd8\xff")):
+		info.Format = MediaFormatJPEG
+		config, err := jpeg.DecodeConfig(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, invalidMediaError("JPEG", err)
+		}
+		info.Width, info.Height = config.Width, config.Height
+		return info, nil
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		info.Format = MediaFormatPNG
+		config, err := png.DecodeConfig(io.NewSectionReader(r, 0, size))
+		if err != nil {
+			return nil, invalidMediaError("PNG", err)
+		}
+		info.Width, info.Height = config.Width, config.Height
+		return info, nil
+	case bytes.HasPrefix(head, []byte("GIF8")):
+		info.Format = MediaFormatGIF
+		return info, nil
+	case len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == "WEBP":
+		info.Format = MediaFormatWebP
+		return info, nil
+	case len(head) >= 12 && string(head[4:8]) == "ftyp" && isHEIFBrand(string(head[8:12])):
+		info.Format = MediaFormatHEIF
+		return info, nil
+	case len(head) >= 8 && isTopLevelAtom(string(head[4:8])):
+		return inspectMP4(r, size)
+	default:
+		return nil, NewValidationError(400, "Unsupported media format",
+			"Media must be a JPEG or PNG image or an MP4 or MOV video", "media")
+	}
+}
+
+// Violations checks the media against the Threads image or video
+// specifications and returns every violation found.
+func (m *MediaInfo) Violations() []*MediaViolation {
+	var violations []*MediaViolation
+	add := func(code, field, format string, args ...interface{}) {
+		violations = append(violations, &MediaViolation{Code: code, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if m.Width > 0 && m.Height > 0 {
+		aspect := float64(m.Width) / float64(m.Height)
+		if aspect > MaxMediaAspectRatio || aspect < MinMediaAspectRatio {
+			add(ContainerErrInvalidAspectRatio, "aspect_ratio", "aspect ratio %dx%d is outside 0.01:1 to 10:1", m.Width, m.Height)
+		}
+	}
+
+	if m.MediaType == MediaTypeImage {
+		if m.Format != MediaFormatJPEG && m.Format != MediaFormatPNG {
+			add(ContainerErrUnknown, "format", "%s images are not supported; use JPEG or PNG", m.Format)
+		}
+		if m.Size > MaxImageFileSize {
+			add(ContainerErrUnknown, "size", "image file is %d bytes; maximum is 8 MB", m.Size)
+		}
+		return violations
+	}
+
+	if m.Size > MaxVideoFileSize {
+		add(ContainerErrFailedProcessingVideo, "size", "video file is %d bytes; maximum is 1 GB", m.Size)
+	}
+	if !m.FastStart {
+		add(ContainerErrFailedProcessingVideo, "fast_start", "the moov atom must come before the media data")
+	}
+	if !isSupportedVideoCodec(m.VideoCodec) {
+		add(ContainerErrFailedProcessingVideo, "video_codec", "video codec %q is not supported; use H.264 or HEVC", m.VideoCodec)
+	}
+	if m.Width > MaxVideoWidth {
+		add(ContainerErrFailedProcessingVideo, "width", "video is %d pixels wide; maximum is %d", m.Width, MaxVideoWidth)
+	}
+	if m.Duration <= 0 || m.Duration > MaxVideoDuration {
+		add(ContainerErrInvalidDuration, "duration", "video duration %s is outside 0 to %s", m.Duration, MaxVideoDuration)
+	}
+	// Allow for rounding of rates such as 23.976 and 59.94
+	if m.FrameRate < MinVideoFrameRate-0.5 || m.FrameRate > MaxVideoFrameRate+0.5 {
+		add(ContainerErrInvalidFrameRate, "frame_rate", "frame rate %.2f FPS is outside %d to %d", m.FrameRate, MinVideoFrameRate, MaxVideoFrameRate)
+	}
+	if m.BitRate > MaxVideoBitRate {
+		add(ContainerErrInvalidBitRate, "bit_rate", "video bit rate %d bps exceeds 100 Mbps", m.BitRate)
+	}
+
+	if m.AudioCodec == "" {
+		return violations
+	}
+	if m.AudioCodec != "mp4a" {
+		add(ContainerErrFailedProcessingAudio, "audio_codec", "audio codec %q is not supported; use AAC", m.AudioCodec)
+	}
+	if m.AudioSampleRate > MaxAudioSampleRate {
+		add(ContainerErrFailedProcessingAudio, "audio_sample_rate", "audio sample rate %d Hz exceeds 48 kHz", m.AudioSampleRate)
+	}
+	if m.AudioChannels < 1 || m.AudioChannels > MaxAudioChannels {
+		add(ContainerErrInvalidAudioChannels, "audio_channels", "audio has %d channels; use mono or stereo", m.AudioChannels)
+	}
+	if m.AudioBitRate > MaxAudioBitRate {
+		add(ContainerErrInvalidBitRate, "audio_bit_rate", "audio bit rate %d bps exceeds 128 kbps", m.AudioBitRate)
+	}
+	return violations
+}
+
+// Validate returns the violations found by Violations joined into one error,
+// or nil if the media meets the specifications.
+func (m *MediaInfo) Validate() error {
+	violations := m.Violations()
+	errs := make([]error, len(violations))
+	for i, v := range violations {
+		errs[i] = v
+	}
+	return errors.Join(errs...)
+}
+
+func invalidMediaError(format string, err error) error {
+	return NewValidationError(400, "Invalid media file",
+		fmt.Sprintf("Cannot read %s header: %v", format, err), "media")
+}
+
+func isHEIFBrand(brand string) bool {
+	switch brand {
+	case "heic", "heix", "heim", "heis", "mif1", "msf1", "avif":
+		return true
+	default:
+		return false
+	}
+}
+
+func isSupportedVideoCodec(codec string) bool {
+	switch codec {
+	case "avc1", "avc3", "hvc1", "hev1":
+		return true
+	default:
+		return false
+	}
+}
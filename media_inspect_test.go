@@ -0,0 +1,251 @@
+package threads
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// atom encodes an MP4 atom with the given body parts.
+func atom(typ string, body ...[]byte) []byte {
+	b := bytes.Join(body, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(b)))
+	return append(append(out, typ...), b...)
+}
+
+// nestedAtoms returns n atoms of type typ, each containing the next.
+func nestedAtoms(typ string, n int) []byte {
+	b := make([]byte, 0, 8*n)
+	for i := 0; i < n; i++ {
+		b = binary.BigEndian.AppendUint32(b, uint32(8*(n-i)))
+		b = append(b, typ...)
+	}
+	return b
+}
+
+func u16(v uint16) []byte { return binary.BigEndian.AppendUint16(nil, v) }
+func u32(v uint32) []byte { return binary.BigEndian.AppendUint32(nil, v) }
+
+// testVideo describes a synthetic MP4 built by buildTestVideo.
+type testVideo struct {
+	brand           string
+	width, height   int
+	rotated         bool
+	seconds         int
+	fps             int
+	frameBytes      uint32 // Bytes per video sample
+	codec           string
+	audioChannels   uint16
+	audioSampleRate uint32
+	mdatFirst       bool
+}
+
+// buildTestVideo returns an MP4 file with a video track and an AAC audio
+// track. All timescales are 1000.
+func buildTestVideo(v testVideo) []byte {
+	const timescale = 1000
+	duration := uint32(v.seconds * timescale)
+
+	matrix := [9]uint32{0x10000, 0, 0, 0, 0x10000, 0, 0, 0, 0x40000000}
+	if v.rotated {
+		matrix[0], matrix[1], matrix[3], matrix[4] = 0, 0x10000, 0xFFFF0000, 0
+	}
+	var tkhd []byte
+	tkhd = append(tkhd, make([]byte, 40)...) // Version 0 header fields
+	for _, m := range matrix {
+		tkhd = append(tkhd, u32(m)...)
+	}
+	tkhd = append(tkhd, u32(uint32(v.width)<<16)...)
+	tkhd = append(tkhd, u32(uint32(v.height)<<16)...)
+
+	mdhd := append(make([]byte, 12), append(u32(timescale), u32(duration)...)...)
+	hdlr := func(handler string) []byte {
+		return append(make([]byte, 8), append([]byte(handler), make([]byte, 12)...)...)
+	}
+
+	frames := uint32(v.seconds * v.fps)
+	videoEntry := atom(v.codec, make([]byte, 24), u16(uint16(v.width)), u16(uint16(v.height)), make([]byte, 50))
+	videoTrak := atom("trak",
+		atom("tkhd", tkhd),
+		atom("mdia",
+			atom("mdhd", mdhd),
+			atom("hdlr", hdlr("vide")),
+			atom("minf", atom("stbl",
+				atom("stsd", u32(0), u32(1), videoEntry),
+				atom("stts", u32(0), u32(1), u32(frames), u32(timescale/uint32(v.fps))),
+				atom("stsz", u32(0), u32(v.frameBytes), u32(frames)),
+			)),
+		),
+	)
+
+	audioEntry := atom("mp4a", make([]byte, 8), make([]byte, 8), u16(v.audioChannels), u16(16), make([]byte, 4), u32(v.audioSampleRate<<16))
+	if v.audioSampleRate > 0xFFFF {
+		// Rates above 65535 Hz need a QuickTime version 2 sound description
+		rate := binary.BigEndian.AppendUint64(nil, math.Float64bits(float64(v.audioSampleRate)))
+		audioEntry = atom("mp4a", make([]byte, 8), u16(2), make([]byte, 6), u16(3), u16(16), u16(0xFFFE), u16(0), u32(0x10000), u32(72),
+			rate, u32(uint32(v.audioChannels)), make([]byte, 20))
+	}
+	audioTrak := atom("trak",
+		atom("tkhd", make([]byte, 84)),
+		atom("mdia",
+			atom("mdhd", mdhd),
+			atom("hdlr", hdlr("soun")),
+			atom("minf", atom("stbl",
+				atom("stsd", u32(0), u32(1), audioEntry),
+				// 16 KB per second is 128 kbps
+				atom("stsz", u32(0), u32(16000), u32(uint32(v.seconds))),
+			)),
+		),
+	)
+
+	moov := atom("moov", atom("mvhd", make([]byte, 12), u32(timescale), u32(duration), make([]byte, 80)), videoTrak, audioTrak)
+	mdat := atom("mdat", make([]byte, 64))
+	ftyp := atom("ftyp", []byte(v.brand), u32(0), []byte("isom"))
+	if v.mdatFirst {
+		return bytes.Join([][]byte{ftyp, mdat, moov}, nil)
+	}
+	return bytes.Join([][]byte{ftyp, moov, mdat}, nil)
+}
+
+func validTestVideo() testVideo {
+	return testVideo{
+		brand: "isom", width: 1080, height: 1920, seconds: 10, fps: 30, frameBytes: 20000,
+		codec: "avc1", audioChannels: 2, audioSampleRate: 48000,
+	}
+}
+
+func TestInspectMedia_Video(t *testing.T) {
+	v := validTestVideo()
+	v.width, v.height, v.rotated = 1920, 1080, true
+	data := buildTestVideo(v)
+
+	info, err := InspectMedia(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MediaType != MediaTypeVideo || info.Format != MediaFormatMP4 || info.VideoCodec != "avc1" {
+		t.Errorf("unexpected type, format or codec: %+v", info)
+	}
+	if info.Width != 1080 || info.Height != 1920 || info.Duration != 10*time.Second || info.FrameRate != 30 || !info.FastStart {
+		t.Errorf("unexpected video properties: %+v", info)
+	}
+	if info.BitRate != 4_800_000 || info.AudioCodec != "mp4a" || info.AudioChannels != 2 || info.AudioSampleRate != 48000 || info.AudioBitRate != 128_000 {
+		t.Errorf("unexpected bit rates or audio properties: %+v", info)
+	}
+	if err := info.Validate(); err != nil {
+		t.Errorf("expected a valid video, got %v", err)
+	}
+}
+
+func TestInspectMedia_VideoViolations(t *testing.T) {
+	v := validTestVideo()
+	v.brand = "qt  "
+	v.width, v.height = 2400, 200
+	v.seconds = 400
+	v.fps = 20
+	v.frameBytes = 1_000_000
+	v.codec = "mp4v"
+	v.audioChannels = 6
+	v.audioSampleRate = 96000
+	v.mdatFirst = true
+	data := buildTestVideo(v)
+
+	info, err := InspectMedia(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Format != MediaFormatMOV || info.AudioSampleRate != 96000 || info.AudioChannels != 6 || info.FastStart {
+		t.Errorf("expected a MOV with 96 kHz 6 channel audio and no fast start, got %+v", info)
+	}
+
+	err = info.Validate()
+	for _, sentinel := range []error{
+		ErrContainerInvalidAspectRatio,
+		ErrContainerInvalidDuration,
+		ErrContainerInvalidFrameRate,
+		ErrContainerInvalidBitRate,
+		ErrContainerInvalidAudioChannels,
+		ErrContainerFailedProcessingAudio,
+		ErrContainerFailedProcessingVideo,
+	} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("expected a violation matching %v", sentinel)
+		}
+	}
+	if errors.Is(err, ErrContainerInvalidAudioChannelLayout) {
+		t.Error("unexpected channel layout violation")
+	}
+
+	fields := map[string]bool{}
+	for _, violation := range info.Violations() {
+		fields[violation.Field] = true
+	}
+	for _, field := range []string{"fast_start", "video_codec", "aspect_ratio", "audio_sample_rate"} {
+		if !fields[field] {
+			t.Errorf("expected a %s violation, got %v", field, fields)
+		}
+	}
+}
+
+func TestInspectMediaFile_Images(t *testing.T) {
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1100, 100))); err != nil {
+		t.Fatal(err)
+	}
+	pngPath := filepath.Join(dir, "wide.png")
+	if err := os.WriteFile(pngPath, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := InspectMediaFile(pngPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MediaType != MediaTypeImage || info.Format != MediaFormatPNG || info.Width != 1100 || info.Height != 100 {
+		t.Errorf("unexpected PNG info: %+v", info)
+	}
+	if err := info.Validate(); !errors.Is(err, ErrContainerInvalidAspectRatio) {
+		t.Errorf("expected an aspect ratio violation, got %v", err)
+	}
+
+	buf.Reset()
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480)), nil); err != nil {
+		t.Fatal(err)
+	}
+	info, err = InspectMedia(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Format != MediaFormatJPEG || info.Width != 640 || info.Height != 480 || info.Validate() != nil {
+		t.Errorf("expected a valid JPEG, got %+v", info)
+	}
+
+	gif := []byte("GIF89a\x01\x00\x01\x00")
+	if info, err := InspectMedia(bytes.NewReader(gif), int64(len(gif))); err != nil || !errors.Is(info.Validate(), ErrContainerUnknown) {
+		t.Errorf("expected GIF to be reported as unsupported, got %+v, %v", info, err)
+	}
+}
+
+func TestInspectMedia_InvalidInput(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"unknown":   []byte("hello, world"),
+		"truncated": buildTestVideo(validTestVideo())[:100],
+		"no moov":   atom("ftyp", []byte("isom")),
+		"nested":    nestedAtoms("moov", 1<<20),
+		"deep trak": atom("moov", atom("trak", nestedAtoms("mdia", 100))),
+	} {
+		if _, err := InspectMedia(bytes.NewReader(data), int64(len(data))); !IsValidationError(err) {
+			t.Errorf("%s: expected a ValidationError, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package threads
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// maxAtomPayload caps how much of a single atom inspectMP4 reads, so a
+// corrupt size cannot cause a huge allocation.
+const maxAtomPayload = 32 << 20
+
+// maxAtomDepth caps how deeply inspectMP4 descends into nested atoms. The
+// deepest atom it reads, moov/trak/mdia/minf/stbl/stsd, is at depth 5.
+const maxAtomDepth = 8
+
+// mp4Track holds what inspectMP4 learns about one trak atom.
+type mp4Track struct {
+	handler       string // "vide" or "soun"
+	codec         string
+	width, height int // From tkhd, or from the sample entry if tkhd has none
+	rotated       bool
+	timescale     uint32
+	duration      uint64
+	samples       uint64
+	bytes         uint64
+	channels      int
+	sampleRate    int
+}
+
+// seconds returns the track duration in seconds.
+func (t *mp4Track) seconds() float64 {
+	if t.timescale == 0 {
+		return 0
+	}
+	return float64(t.duration) / float64(t.timescale)
+}
+
+// bitRate returns the average bit rate of the track's samples.
+func (t *mp4Track) bitRate() int64 {
+	if s := t.seconds(); s > 0 {
+		return int64(math.Round(float64(t.bytes) * 8 / s))
+	}
+	return 0
+}
+
+type mp4Parser struct {
+	r io.ReaderAt
+
+	brand          string
+	sawMoov        bool
+	sawMdat        bool
+	fastStart      bool
+	movieTimescale uint32
+	movieDuration  uint64
+	tracks         []*mp4Track
+}
+
+// isTopLevelAtom reports whether typ can start an MP4 or MOV file.
+func isTopLevelAtom(typ string) bool {
+	switch typ {
+	case "ftyp", "moov", "mdat", "free", "skip", "wide", "pnot":
+		return true
+	default:
+		return false
+	}
+}
+
+// inspectMP4 reads the atoms of an MP4 or MOV file.
+func inspectMP4(r io.ReaderAt, size int64) (*MediaInfo, error) {
+	p := &mp4Parser{r: r}
+	if err := p.walk(0, size, "", nil, 0); err != nil {
+		return nil, err
+	}
+	if !p.sawMoov {
+		return nil, NewValidationError(400, "Invalid media file", "Video has no moov atom", "media")
+	}
+
+	info := &MediaInfo{
+		MediaType: MediaTypeVideo,
+		Format:    MediaFormatMP4,
+		Size:      size,
+		FastStart: p.fastStart,
+	}
+	if p.brand == "qt  " {
+		info.Format = MediaFormatMOV
+	}
+	if p.movieTimescale > 0 {
+		info.Duration = time.Duration(float64(p.movieDuration) / float64(p.movieTimescale) * float64(time.Second))
+	}
+
+	var video, audio *mp4Track
+	for _, t := range p.tracks {
+		switch {
+		case t.handler == "vide" && video == nil:
+			video = t
+		case t.handler == "soun" && audio == nil:
+			audio = t
+		}
+	}
+
+	if video == nil {
+		return nil, NewValidationError(400, "Invalid media file", "Video has no video track", "media")
+	}
+	info.VideoCodec = video.codec
+	info.Width, info.Height = video.width, video.height
+	if video.rotated {
+		info.Width, info.Height = video.height, video.width
+	}
+	if info.Duration == 0 {
+		info.Duration = time.Duration(video.seconds() * float64(time.Second))
+	}
+	if s := video.seconds(); s > 0 {
+		info.FrameRate = float64(video.samples) / s
+	}
+	info.BitRate = video.bitRate()
+
+	if audio != nil {
+		info.AudioCodec = audio.codec
+		info.AudioChannels = audio.channels
+		info.AudioSampleRate = audio.sampleRate
+		info.AudioBitRate = audio.bitRate()
+	}
+	return info, nil
+}
+
+// walk reads the atoms between start and end, which are the body of an atom
+// of type parent, or the top level of the file if parent is empty. Atoms are
+// only read where they belong: moov at the top level, trak in moov and
+// mdia, minf and stbl in that order under trak, which also bounds the
+// recursion. track is the trak atom being read, or nil outside of one.
+func (p *mp4Parser) walk(start, end int64, parent string, track *mp4Track, depth int) error {
+	if depth > maxAtomDepth {
+		return NewValidationError(400, "Invalid media file", "Video atoms are nested too deeply", "media")
+	}
+
+	for off := start; off+8 <= end; {
+		header := make([]byte, 16)
+		if _, err := p.r.ReadAt(header[:8], off); err != nil {
+			return invalidMediaError("video", err)
+		}
+		size := int64(binary.BigEndian.Uint32(header))
+		typ := string(header[4:8])
+		headerLen := int64(8)
+
+		switch size {
+		case 0: // Extends to the end of the enclosing atom
+			size = end - off
+		case 1: // 64-bit size follows the type
+			if _, err := p.r.ReadAt(header[8:16], off+8); err != nil {
+				return invalidMediaError("video", err)
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:]))
+			headerLen = 16
+		}
+		if size < headerLen || size > end-off {
+			return NewValidationError(400, "Invalid media file",
+				fmt.Sprintf("Atom %q at offset %d has an invalid size", typ, off), "media")
+		}
+		bodyStart, bodyEnd := off+headerLen, off+size
+
+		var err error
+		switch parent + "/" + typ {
+		case "/ftyp":
+			var body []byte
+			if body, err = p.read(typ, bodyStart, bodyEnd); err == nil && len(body) >= 4 {
+				p.brand = string(body[:4])
+			}
+		case "/mdat":
+			p.sawMdat = true
+		case "/moov":
+			p.sawMoov = true
+			p.fastStart = !p.sawMdat
+			err = p.walk(bodyStart, bodyEnd, typ, nil, depth+1)
+		case "moov/mvhd":
+			err = p.parse(typ, bodyStart, bodyEnd, func(b []byte) bool {
+				p.movieTimescale, p.movieDuration = parseMediaHeader(b)
+				return p.movieTimescale > 0
+			})
+		case "moov/trak":
+			t := &mp4Track{}
+			p.tracks = append(p.tracks, t)
+			err = p.walk(bodyStart, bodyEnd, typ, t, depth+1)
+		case "trak/mdia", "mdia/minf", "minf/stbl":
+			err = p.walk(bodyStart, bodyEnd, typ, track, depth+1)
+		case "trak/tkhd":
+			err = p.parse(typ, bodyStart, bodyEnd, track.parseTrackHeader)
+		case "mdia/mdhd":
+			err = p.parse(typ, bodyStart, bodyEnd, func(b []byte) bool {
+				track.timescale, track.duration = parseMediaHeader(b)
+				return track.timescale > 0
+			})
+		case "mdia/hdlr":
+			err = p.parse(typ, bodyStart, bodyEnd, func(b []byte) bool {
+				if len(b) < 12 {
+					return false
+				}
+				track.handler = string(b[8:12])
+				return true
+			})
+		case "stbl/stsd":
+			err = p.parse(typ, bodyStart, bodyEnd, track.parseSampleDescription)
+		case "stbl/stts":
+			err = p.parse(typ, bodyStart, bodyEnd, track.parseTimeToSample)
+		case "stbl/stsz":
+			err = p.parse(typ, bodyStart, bodyEnd, track.parseSampleSizes)
+		}
+		if err != nil {
+			return err
+		}
+		off += size
+	}
+	return nil
+}
+
+// read returns the body of an atom.
+func (p *mp4Parser) read(typ string, start, end int64) ([]byte, error) {
+	if end-start > maxAtomPayload {
+		return nil, NewValidationError(400, "Invalid media file",
+			fmt.Sprintf("Atom %q is too large to inspect", typ), "media")
+	}
+	body := make([]byte, end-start)
+	if _, err := p.r.ReadAt(body, start); err != nil {
+		return nil, invalidMediaError("video", err)
+	}
+	return body, nil
+}
+
+// parse reads the body of an atom and passes it to parseBody, which reports
+// whether the body was well formed.
+func (p *mp4Parser) parse(typ string, start, end int64, parseBody func([]byte) bool) error {
+	body, err := p.read(typ, start, end)
+	if err != nil {
+		return err
+	}
+	if !parseBody(body) {
+		return NewValidationError(400, "Invalid media file", fmt.Sprintf("Atom %q is malformed", typ), "media")
+	}
+	return nil
+}
+
+// parseMediaHeader returns the timescale and duration from an mvhd or mdhd
+// body, or a zero timescale if the body is too short.
+func parseMediaHeader(b []byte) (timescale uint32, duration uint64) {
+	if len(b) >= 32 && b[0] == 1 {
+		return binary.BigEndian.Uint32(b[20:24]), binary.BigEndian.Uint64(b[24:32])
+	}
+	if len(b) >= 20 && b[0] == 0 {
+		return binary.BigEndian.Uint32(b[12:16]), uint64(binary.BigEndian.Uint32(b[16:20]))
+	}
+	return 0, 0
+}
+
+// parseTrackHeader reads the display size and rotation from a tkhd body.
+func (t *mp4Track) parseTrackHeader(b []byte) bool {
+	matrix := 40 // Offset of the transformation matrix in version 0
+	if len(b) > 0 && b[0] == 1 {
+		matrix = 52
+	}
+	if len(b) < matrix+44 {
+		return false
+	}
+
+	// A 90 or 270 degree rotation has zero a and d matrix entries
+	a := int32(binary.BigEndian.Uint32(b[matrix:]))
+	d := int32(binary.BigEndian.Uint32(b[matrix+16:]))
+	t.rotated = a == 0 && d == 0
+
+	// Width and height are 16.16 fixed point
+	if w, h := int(binary.BigEndian.Uint32(b[matrix+36:])>>16), int(binary.BigEndian.Uint32(b[matrix+40:])>>16); w > 0 && h > 0 {
+		t.width, t.height = w, h
+	}
+	return true
+}
+
+// parseSampleDescription reads the codec and format from the first sample
+// entry of an stsd body.
+func (t *mp4Track) parseSampleDescription(b []byte) bool {
+	if len(b) < 16 || binary.BigEndian.Uint32(b[4:8]) == 0 {
+		return false
+	}
+	t.codec = string(b[12:16])
+	entry := b[16:] // Sample entry after its size and type
+
+	switch t.handler {
+	case "vide":
+		if len(entry) < 28 {
+			return false
+		}
+		if t.width == 0 || t.height == 0 {
+			t.width = int(binary.BigEndian.Uint16(entry[24:26]))
+			t.height = int(binary.BigEndian.Uint16(entry[26:28]))
+		}
+	case "soun":
+		if len(entry) < 28 {
+			return false
+		}
+		// QuickTime version 2 sound descriptions move the format fields
+		if binary.BigEndian.Uint16(entry[8:10]) == 2 {
+			if len(entry) < 44 {
+				return false
+			}
+			t.sampleRate = int(math.Float64frombits(binary.BigEndian.Uint64(entry[32:40])))
+			t.channels = int(binary.BigEndian.Uint32(entry[40:44]))
+			return true
+		}
+		t.channels = int(binary.BigEndian.Uint16(entry[16:18]))
+		t.sampleRate = int(binary.BigEndian.Uint32(entry[24:28]) >> 16)
+	}
+	return true
+}
+
+// parseTimeToSample counts the samples in an stts body.
+func (t *mp4Track) parseTimeToSample(b []byte) bool {
+	if len(b) < 8 {
+		return false
+	}
+	count := int(binary.BigEndian.Uint32(b[4:8]))
+	if int64(len(b)) < 8+8*int64(count) {
+		return false
+	}
+	t.samples = 0
+	for i := 0; i < count; i++ {
+		t.samples += uint64(binary.BigEndian.Uint32(b[8+8*i:]))
+	}
+	return true
+}
+
+// parseSampleSizes totals the sample sizes in an stsz body.
+func (t *mp4Track) parseSampleSizes(b []byte) bool {
+	if len(b) < 12 {
+		return false
+	}
+	size := uint64(binary.BigEndian.Uint32(b[4:8]))
+	count := int(binary.BigEndian.Uint32(b[8:12]))
+	if size != 0 {
+		t.bytes = size * uint64(count)
+		return true
+	}
+	if int64(len(b)) < 12+4*int64(count) {
+		return false
+	}
+	t.bytes = 0
+	for i := 0; i < count; i++ {
+		t.bytes += uint64(binary.BigEndian.Uint32(b[12+4*i:]))
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+package threads
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TempMediaServerOptions configures a TempMediaServer.
+type TempMediaServerOptions struct {
+	// Addr is the TCP address to listen on. Default ":0" picks a free port
+	// on all interfaces.
+	Addr string
+
+	// PublicURL is the base URL at which the Threads servers reach the
+	// listener, such as the address of a tunnel or reverse proxy in front of
+	// it. Default is "http://" followed by the listener address, which only
+	// works if that address is publicly reachable.
+	PublicURL string
+
+	// Dir is the directory files are stored in while they are served.
+	// Default is a new temporary directory, removed by Close.
+	Dir string
+
+	// MaxAge is how long a file is served if its cleanup is never called,
+	// for example because the container status is never checked.
+	// Default DefaultTempMediaMaxAge.
+	MaxAge time.Duration
+}
+
+// TempMediaServer is a MediaHost that serves uploaded files from an
+// embedded HTTP server. Each file is stored in a temporary file and served
+// at an unguessable URL until its cleanup is called, which the client does
+// once the container created from it has been processed, or until MaxAge
+// passes. Requests for any other path are answered with 404 Not Found.
+type TempMediaServer struct {
+	opts      TempMediaServerOptions
+	publicURL string
+	ownsDir   bool
+	listener  net.Listener
+	server    *http.Server
+	closeOnce sync.Once
+
+	mu    sync.Mutex
+	files map[string]*tempMediaFile // By URL token
+}
+
+type tempMediaFile struct {
+	path    string
+	name    string
+	modTime time.Time
+	expires time.Time
+}
+
+// NewTempMediaServer starts a TempMediaServer. Call Close to stop it and
+// remove its files.
+func NewTempMediaServer(opts TempMediaServerOptions) (*TempMediaServer, error) {
+	if opts.Addr == "" {
+		opts.Addr = ":0"
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = DefaultTempMediaMaxAge
+	}
+
+	publicURL := strings.TrimRight(opts.PublicURL, "/")
+	if publicURL != "" {
+		u, err := url.Parse(publicURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, NewValidationError(400, "Invalid public URL", "PublicURL must be an absolute HTTP or HTTPS URL", "public_url")
+		}
+	}
+
+	s := &TempMediaServer{opts: opts, files: make(map[string]*tempMediaFile)}
+	if s.opts.Dir == "" {
+		dir, err := os.MkdirTemp("", "threads-media-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create media directory: %w", err)
+		}
+		s.opts.Dir = dir
+		s.ownsDir = true
+	} else if err := os.MkdirAll(s.opts.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		s.removeDir()
+		return nil, fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+	if publicURL == "" {
+		publicURL = "http://" + listener.Addr().String()
+	}
+	s.publicURL = publicURL
+	s.listener = listener
+	s.server = &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() { _ = s.server.Serve(listener) }()
+	return s, nil
+}
+
+// URL returns the base URL of the served files.
+func (s *TempMediaServer) URL() string {
+	return s.publicURL
+}
+
+// Upload stores the file and returns the URL it is served at, along with a
+// cleanup function that stops serving it and removes it.
+func (s *TempMediaServer) Upload(ctx context.Context, file *MediaFile) (string, func(), error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	s.removeExpired(time.Now())
+
+	token, err := newMediaToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	f, err := os.CreateTemp(s.opts.Dir, "media-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create media file: %w", err)
+	}
+	_, err = io.Copy(f, file.Reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", nil, fmt.Errorf("failed to store media file: %w", err)
+	}
+
+	name := mediaFileName(file)
+	now := time.Now()
+	s.mu.Lock()
+	s.files[token] = &tempMediaFile{path: f.Name(), name: name, modTime: now, expires: now.Add(s.opts.MaxAge)}
+	s.mu.Unlock()
+
+	var once sync.Once
+	cleanup := func() {
+		once.Do(func() { s.remove(token) })
+	}
+	return s.publicURL + "/" + token + "/" + url.PathEscape(name), cleanup, nil
+}
+
+// ServeHTTP serves the stored files to GET and HEAD requests.
+func (s *TempMediaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	s.mu.Lock()
+	file, ok := s.files[token]
+	s.mu.Unlock()
+	if !ok || file.name != name {
+		http.NotFound(w, r)
+		return
+	}
+	if time.Now().After(file.expires) {
+		s.remove(token)
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(file.path)
+	if err != nil {
+		// Removed by a concurrent cleanup
+		http.NotFound(w, r)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.ServeContent(w, r, file.name, file.modTime, f)
+}
+
+// Close stops the server and removes all stored files.
+func (s *TempMediaServer) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.server.Close()
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+
+		s.mu.Lock()
+		files := s.files
+		s.files = make(map[string]*tempMediaFile)
+		s.mu.Unlock()
+		for _, file := range files {
+			_ = os.Remove(file.path)
+		}
+		s.removeDir()
+	})
+	return err
+}
+
+// remove stops serving the file with the given token and deletes it.
+func (s *TempMediaServer) remove(token string) {
+	s.mu.Lock()
+	file, ok := s.files[token]
+	delete(s.files, token)
+	s.mu.Unlock()
+
+	if ok {
+		_ = os.Remove(file.path)
+	}
+}
+
+// removeExpired removes files served for longer than MaxAge.
+func (s *TempMediaServer) removeExpired(now time.Time) {
+	s.mu.Lock()
+	var expired []string
+	for token, file := range s.files {
+		if now.After(file.expires) {
+			expired = append(expired, token)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, token := range expired {
+		s.remove(token)
+	}
+}
+
+func (s *TempMediaServer) removeDir() {
+	if s.ownsDir {
+		_ = os.RemoveAll(s.opts.Dir)
+	}
+}
+
+// newMediaToken returns a random token that makes a file's URL unguessable.
+func newMediaToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate media token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// mediaFileName returns the name to serve a file under, keeping the
+// extension used to tell its content type.
+func mediaFileName(file *MediaFile) string {
+	name := path.Base(strings.ReplaceAll(file.Name, "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		name = ""
+	}
+	if name == "" {
+		name = strings.ToLower(file.MediaType)
+	}
+	if name == "" {
+		name = "media"
+	}
+	return name
+}
@@ -0,0 +1,31 @@
+package threads
+
+// RoundTripFunc performs a single HTTP attempt for the given request options.
+// It returns the wrapped *Response (which may be non-nil alongside an error
+// for HTTP 4xx/5xx responses) and any error produced by the attempt.
+type RoundTripFunc func(opts *RequestOptions, accessToken string) (*Response, error)
+
+// Middleware wraps a RoundTripFunc with cross-cutting behaviour such as custom
+// headers, request signing, audit logging, metrics or fault injection.
+//
+// Middleware runs inside HTTPClient.Do's retry loop, so it is invoked once per
+// attempt rather than once per logical request. A middleware may modify opts
+// (for example opts.Headers) before calling next, inspect or replace the
+// returned *Response and error, or short-circuit by returning without calling
+// next at all. The accessToken is passed through for signing purposes and
+// must never be logged.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chainMiddleware composes middlewares around final. The first middleware in
+// the slice is the outermost, so it sees the request first and the response
+// last. Nil entries are skipped.
+func chainMiddleware(final RoundTripFunc, middlewares []Middleware) RoundTripFunc {
+	rt := final
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] == nil {
+			continue
+		}
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
@@ -0,0 +1,173 @@
+package threads
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMiddlewareTestHTTPClient creates an HTTPClient with the given middleware chain.
+func newMiddlewareTestHTTPClient(t *testing.T, handler http.Handler, maxRetries int, middleware ...Middleware) *HTTPClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewHTTPClient(&Config{
+		HTTPTimeout: 5 * time.Second,
+		Logger:      &noopLogger{},
+		RetryConfig: &RetryConfig{
+			MaxRetries:    maxRetries,
+			InitialDelay:  time.Millisecond,
+			MaxDelay:      time.Millisecond,
+			BackoffFactor: 1.0,
+		},
+		BaseURL:    server.URL,
+		Middleware: middleware,
+	}, nil)
+}
+
+func TestMiddleware_OrderAndHeaderInjection(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Outer") != "1" || r.Header.Get("X-Inner") != "1" {
+			t.Errorf("expected injected headers, got %v", r.Header)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{}`))
+	}
+
+	var order []string
+	tag := func(name, header string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(opts *RequestOptions, accessToken string) (*Response, error) {
+				order = append(order, name+":before")
+				if opts.Headers == nil {
+					opts.Headers = map[string]string{}
+				}
+				opts.Headers[header] = "1"
+				resp, err := next(opts, accessToken)
+				order = append(order, name+":after")
+				return resp, err
+			}
+		}
+	}
+
+	httpClient := newMiddlewareTestHTTPClient(t, http.HandlerFunc(handler), 0,
+		tag("outer", "X-Outer"), nil, tag("inner", "X-Inner"))
+
+	if _, err := httpClient.GET("/test", nil, "token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"outer:before", "inner:before", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d]: expected %s, got %s", i, want[i], order[i])
+		}
+	}
+}
+
+func TestMiddleware_RunsOnEveryAttempt(t *testing.T) {
+	var serverCalls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&serverCalls, 1) < 3 {
+			w.WriteHeader(500)
+			_, _ = w.Write([]byte(`{"error":{"message":"boom","code":2,"is_transient":true}}`))
+			return
+		}
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{}`))
+	}
+
+	var calls, failures int
+	var lastStatus int
+	observe := func(next RoundTripFunc) RoundTripFunc {
+		return func(opts *RequestOptions, accessToken string) (*Response, error) {
+			calls++
+			if accessToken != "token" {
+				t.Errorf("expected access token to be passed through, got %q", accessToken)
+			}
+			resp, err := next(opts, accessToken)
+			if err != nil {
+				failures++
+			}
+			if resp != nil {
+				lastStatus = resp.StatusCode
+			}
+			return resp, err
+		}
+	}
+
+	httpClient := newMiddlewareTestHTTPClient(t, http.HandlerFunc(handler), 3, observe)
+
+	if _, err := httpClient.GET("/test", nil, "token"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected middleware to run 3 times, got %d", calls)
+	}
+	if failures != 2 {
+		t.Errorf("expected 2 failed attempts observed, got %d", failures)
+	}
+	if lastStatus != 200 {
+		t.Errorf("expected last observed status 200, got %d", lastStatus)
+	}
+}
+
+func TestMiddleware_FaultInjectionShortCircuits(t *testing.T) {
+	var serverCalls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&serverCalls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{}`))
+	}
+
+	injected := 0
+	faulty := func(next RoundTripFunc) RoundTripFunc {
+		return func(opts *RequestOptions, accessToken string) (*Response, error) {
+			if injected == 0 {
+				injected++
+				return nil, NewNetworkError(0, "injected", "fault injection", true)
+			}
+			return next(opts, accessToken)
+		}
+	}
+
+	httpClient := newMiddlewareTestHTTPClient(t, http.HandlerFunc(handler), 1, faulty)
+
+	resp, err := httpClient.GET("/test", nil, "token")
+	if err != nil {
+		t.Fatalf("expected retry to recover from injected fault, got: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if got := atomic.LoadInt32(&serverCalls); got != 1 {
+		t.Errorf("expected the injected attempt to skip the server, got %d server calls", got)
+	}
+}
+
+func TestMiddleware_NilResponseIsAnError(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the server should not be called")
+	}
+	broken := func(next RoundTripFunc) RoundTripFunc {
+		return func(opts *RequestOptions, accessToken string) (*Response, error) {
+			return nil, nil
+		}
+	}
+
+	httpClient := newMiddlewareTestHTTPClient(t, http.HandlerFunc(handler), 1, broken)
+
+	resp, err := httpClient.GET("/test", nil, "token")
+	if err == nil || resp != nil {
+		t.Fatalf("expected an error for a nil response, got %v, %v", resp, err)
+	}
+}
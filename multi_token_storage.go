@@ -0,0 +1,231 @@
+package threads
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MultiTokenStorage stores tokens for many accounts keyed by user ID.
+// It backs ClientPool, where each account gets its own Client.
+type MultiTokenStorage interface {
+	// StoreToken saves the token for userID, replacing any existing one.
+	StoreToken(userID UserID, token *TokenInfo) error
+
+	// LoadToken retrieves the token for userID.
+	// Should return an error if no token is stored for the account.
+	LoadToken(userID UserID) (*TokenInfo, error)
+
+	// DeleteToken removes the token for userID.
+	// Deleting a token that does not exist is not an error.
+	DeleteToken(userID UserID) error
+
+	// ListUserIDs returns the IDs of all accounts with a stored token.
+	ListUserIDs() ([]UserID, error)
+}
+
+// NewAccountTokenStorage adapts one account of a MultiTokenStorage to the
+// single-slot TokenStorage interface used by Client.
+func NewAccountTokenStorage(storage MultiTokenStorage, userID UserID) TokenStorage {
+	return &accountTokenStorage{storage: storage, userID: userID}
+}
+
+type accountTokenStorage struct {
+	storage MultiTokenStorage
+	userID  UserID
+}
+
+func (a *accountTokenStorage) Store(token *TokenInfo) error {
+	return a.storage.StoreToken(a.userID, token)
+}
+
+func (a *accountTokenStorage) Load() (*TokenInfo, error) {
+	return a.storage.LoadToken(a.userID)
+}
+
+func (a *accountTokenStorage) Delete() error {
+	return a.storage.DeleteToken(a.userID)
+}
+
+// MemoryMultiTokenStorage keeps tokens for many accounts in memory.
+type MemoryMultiTokenStorage struct {
+	mu     sync.RWMutex
+	tokens map[UserID]*TokenInfo
+}
+
+// NewMemoryMultiTokenStorage creates an empty in-memory multi-account store.
+func NewMemoryMultiTokenStorage() *MemoryMultiTokenStorage {
+	return &MemoryMultiTokenStorage{tokens: make(map[UserID]*TokenInfo)}
+}
+
+// StoreToken saves the token for userID in memory
+func (m *MemoryMultiTokenStorage) StoreToken(userID UserID, token *TokenInfo) error {
+	if !userID.Valid() {
+		return NewValidationError(400, "User ID is required", "Cannot store a token without a user ID", "user_id")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[userID] = token
+	return nil
+}
+
+// LoadToken retrieves the token for userID from memory
+func (m *MemoryMultiTokenStorage) LoadToken(userID UserID) (*TokenInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	token, ok := m.tokens[userID]
+	if !ok {
+		return nil, NewAuthenticationError(401, "No token stored", fmt.Sprintf("No token found in memory storage for user %s", userID))
+	}
+	return token, nil
+}
+
+// DeleteToken removes the token for userID from memory
+func (m *MemoryMultiTokenStorage) DeleteToken(userID UserID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, userID)
+	return nil
+}
+
+// ListUserIDs returns the stored account IDs in sorted order
+func (m *MemoryMultiTokenStorage) ListUserIDs() ([]UserID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]UserID, 0, len(m.tokens))
+	for id := range m.tokens {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// FileMultiTokenStorage stores one token file per account in a directory,
+// using FileTokenStorage (and therefore atomic writes, 0600 permissions and
+// file locking) for each account.
+type FileMultiTokenStorage struct {
+	dir    string
+	secret []byte
+
+	mu       sync.Mutex
+	accounts map[UserID]*FileTokenStorage
+}
+
+// NewFileMultiTokenStorage creates a multi-account store rooted at dir.
+// The directory is created with 0700 permissions if it does not exist.
+func NewFileMultiTokenStorage(dir string) (*FileMultiTokenStorage, error) {
+	if dir == "" {
+		return nil, NewValidationError(400, "Token directory is required", "FileMultiTokenStorage needs a non-empty directory", "dir")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create token directory: %w", err)
+	}
+	return &FileMultiTokenStorage{dir: dir, accounts: make(map[UserID]*FileTokenStorage)}, nil
+}
+
+// NewEncryptedFileMultiTokenStorage is like NewFileMultiTokenStorage but
+// encrypts every token file with a key derived from secret.
+// See NewEncryptedFileTokenStorage for requirements on secret.
+func NewEncryptedFileMultiTokenStorage(dir string, secret []byte) (*FileMultiTokenStorage, error) {
+	if len(secret) < 16 {
+		return nil, NewValidationError(400, "Encryption secret too short", "Secret must be at least 16 bytes", "secret")
+	}
+	s, err := NewFileMultiTokenStorage(dir)
+	if err != nil {
+		return nil, err
+	}
+	s.secret = append([]byte(nil), secret...)
+	return s, nil
+}
+
+// StoreToken atomically writes the token file for userID
+func (f *FileMultiTokenStorage) StoreToken(userID UserID, token *TokenInfo) error {
+	account, err := f.account(userID)
+	if err != nil {
+		return err
+	}
+	return account.Store(token)
+}
+
+// LoadToken reads the token file for userID
+func (f *FileMultiTokenStorage) LoadToken(userID UserID) (*TokenInfo, error) {
+	account, err := f.account(userID)
+	if err != nil {
+		return nil, err
+	}
+	return account.Load()
+}
+
+// DeleteToken removes the token file for userID
+func (f *FileMultiTokenStorage) DeleteToken(userID UserID) error {
+	account, err := f.account(userID)
+	if err != nil {
+		return err
+	}
+	return account.Delete()
+}
+
+// ListUserIDs returns the IDs of all accounts with a token file, sorted
+func (f *FileMultiTokenStorage) ListUserIDs() ([]UserID, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token directory: %w", err)
+	}
+	var ids []UserID
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		id := UserID(strings.TrimSuffix(name, ".json"))
+		if validAccountFileName(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// account returns the per-account file storage, creating it on first use.
+func (f *FileMultiTokenStorage) account(userID UserID) (*FileTokenStorage, error) {
+	if !validAccountFileName(userID) {
+		return nil, NewValidationError(400, "Invalid user ID", fmt.Sprintf("User ID %q cannot be used as a token file name", userID), "user_id")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if account, ok := f.accounts[userID]; ok {
+		return account, nil
+	}
+
+	path := filepath.Join(f.dir, userID.String()+".json")
+	var account *FileTokenStorage
+	var err error
+	if f.secret != nil {
+		account, err = NewEncryptedFileTokenStorage(path, f.secret)
+	} else {
+		account, err = NewFileTokenStorage(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.accounts[userID] = account
+	return account, nil
+}
+
+// validAccountFileName reports whether userID is safe to embed in a file
+// name. Threads user IDs are numeric; anything that could escape the
+// directory is rejected.
+func validAccountFileName(userID UserID) bool {
+	if !userID.Valid() {
+		return false
+	}
+	for _, r := range userID.String() {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+// Package oauth provides HTTP handlers implementing the Threads OAuth 2.0
+// authorization code flow for web applications.
+//
+// The login handler redirects the browser to Threads with a fresh state
+// parameter recorded in a StateStore. The callback handler verifies that
+// state, exchanges the authorization code, upgrades the token to a
+// long-lived one and hands the result to the application:
+//
+//	flow, err := oauth.NewHandler(config, &oauth.Options{
+//		OnSuccess: func(w http.ResponseWriter, r *http.Request, token *threads.TokenInfo, scopes []string) {
+//			if _, err := pool.AddAccount(token); err != nil {
+//				http.Error(w, "failed to save account", http.StatusInternalServerError)
+//				return
+//			}
+//			http.Redirect(w, r, "/", http.StatusFound)
+//		},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	http.Handle("/login", flow.LoginHandler())
+//	http.Handle("/callback", flow.CallbackHandler()) // must match config.RedirectURI
+package oauth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// SuccessFunc receives the token and the scopes the user actually granted,
+// which may be fewer than were requested. It is responsible for persisting
+// the token and writing the response.
+type SuccessFunc func(w http.ResponseWriter, r *http.Request, token *threads.TokenInfo, scopes []string)
+
+// ErrorFunc writes the response for a failed login.
+type ErrorFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+// Options configures a Handler. OnSuccess is required.
+type Options struct {
+	// Scopes to request. Default: the Scopes of the threads.Config.
+	Scopes []string
+
+	// StateStore persists state between login and callback.
+	// Default: NewMemoryStateStore(DefaultStateTTL).
+	StateStore StateStore
+
+	// SkipLongLivedToken keeps the short-lived (1 hour) token instead of
+	// upgrading it with GetLongLivedToken.
+	SkipLongLivedToken bool
+
+	// OnSuccess is called after a successful login.
+	OnSuccess SuccessFunc
+
+	// OnError is called when the login fails. Default: a plain-text error
+	// with status 400 for invalid state or a denied authorization, and 502
+	// when talking to the Threads API fails.
+	OnError ErrorFunc
+}
+
+// Handler serves the login and callback endpoints of the OAuth flow. Each
+// callback uses its own threads.Client, so one Handler serves any number of
+// users concurrently.
+type Handler struct {
+	config    threads.Config
+	scopes    []string
+	store     StateStore
+	longLived bool
+	onSuccess SuccessFunc
+	onError   ErrorFunc
+}
+
+// NewHandler creates a Handler from the application's client configuration.
+// The callback handler must be mounted at config.RedirectURI.
+func NewHandler(config *threads.Config, opts *Options) (*Handler, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if opts == nil || opts.OnSuccess == nil {
+		return nil, fmt.Errorf("OnSuccess is required")
+	}
+
+	h := &Handler{
+		config:    *config,
+		scopes:    opts.Scopes,
+		store:     opts.StateStore,
+		longLived: !opts.SkipLongLivedToken,
+		onSuccess: opts.OnSuccess,
+		onError:   opts.OnError,
+	}
+	// Tokens belong to the user logging in, not to the app's configured storage.
+	h.config.TokenStorage = nil
+	h.config.SetDefaults()
+
+	// Validate the configuration up front rather than on the first login.
+	if _, err := h.newClient(); err != nil {
+		return nil, err
+	}
+	if len(h.scopes) == 0 {
+		h.scopes = h.config.Scopes
+	}
+	if h.store == nil {
+		h.store = NewMemoryStateStore(DefaultStateTTL)
+	}
+	if h.onError == nil {
+		h.onError = defaultError
+	}
+	return h, nil
+}
+
+// LoginHandler returns the handler that starts a login by redirecting to the
+// Threads authorization page.
+func (h *Handler) LoginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, err := h.newClient()
+		if err != nil {
+			h.onError(w, r, err)
+			return
+		}
+		authURL, state, err := client.GetAuthURL(h.scopes)
+		if err != nil {
+			h.onError(w, r, err)
+			return
+		}
+		if err := h.store.Save(w, r, state); err != nil {
+			h.onError(w, r, fmt.Errorf("failed to save state: %w", err))
+			return
+		}
+		http.Redirect(w, r, authURL, http.StatusFound)
+	})
+}
+
+// CallbackHandler returns the handler for the redirect URI. It validates the
+// state, exchanges the code, optionally upgrades to a long-lived token and
+// calls OnSuccess with the token and granted scopes.
+func (h *Handler) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		state := query.Get("state")
+
+		// Consume the state even when the user denied access, so it cannot be reused.
+		stateErr := h.store.Consume(w, r, state)
+
+		if reason := query.Get("error"); reason != "" {
+			description := query.Get("error_description")
+			if description == "" {
+				description = query.Get("error_reason")
+			}
+			h.onError(w, r, threads.NewAuthenticationError(400, "Authorization denied: "+reason, description))
+			return
+		}
+		if stateErr != nil {
+			h.onError(w, r, stateErr)
+			return
+		}
+
+		client, err := h.newClient()
+		if err != nil {
+			h.onError(w, r, err)
+			return
+		}
+		ctx := r.Context()
+
+		// The store has already matched state against this browser.
+		if err := client.ExchangeCodeForToken(ctx, query.Get("code"), state, state); err != nil {
+			h.onError(w, r, err)
+			return
+		}
+		if h.longLived {
+			if err := client.GetLongLivedToken(ctx); err != nil {
+				h.onError(w, r, err)
+				return
+			}
+		}
+
+		debug, err := client.DebugToken(ctx, "")
+		if err != nil {
+			h.onError(w, r, fmt.Errorf("failed to read granted scopes: %w", err))
+			return
+		}
+
+		h.onSuccess(w, r, client.GetTokenInfo(), debug.Data.Scopes)
+	})
+}
+
+func (h *Handler) newClient() (*threads.Client, error) {
+	config := h.config
+	return threads.NewClient(&config)
+}
+
+func defaultError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, ErrInvalidState) || threads.IsAuthenticationError(err) || threads.IsValidationError(err) {
+		status = http.StatusBadRequest
+	}
+	http.Error(w, http.StatusText(status), status)
+}
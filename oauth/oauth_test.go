@@ -0,0 +1,200 @@
+package oauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	threads "github.com/tirthpatell/threads-go"
+	"github.com/tirthpatell/threads-go/threadstest"
+)
+
+type loginResult struct {
+	token  *threads.TokenInfo
+	scopes []string
+}
+
+func newTestHandler(t *testing.T, store StateStore) (*Handler, *threadstest.Server, chan loginResult) {
+	t.Helper()
+	srv := threadstest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	results := make(chan loginResult, 1)
+	h, err := NewHandler(srv.Config(), &Options{
+		StateStore: store,
+		OnSuccess: func(w http.ResponseWriter, r *http.Request, token *threads.TokenInfo, scopes []string) {
+			results <- loginResult{token: token, scopes: scopes}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	return h, srv, results
+}
+
+// startLogin runs the login handler and returns the issued state and cookies.
+func startLogin(t *testing.T, h *Handler) (string, []*http.Cookie) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.LoginHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/login", nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("expected redirect, got %d", rec.Code)
+	}
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := location.Query().Get("state")
+	if state == "" {
+		t.Fatal("expected state in authorization URL")
+	}
+	return state, rec.Result().Cookies()
+}
+
+func callback(h *Handler, query url.Values, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/callback?"+query.Encode(), nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	h.CallbackHandler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_LoginAndCallback(t *testing.T) {
+	for name, store := range map[string]StateStore{
+		"memory": NewMemoryStateStore(0),
+		"cookie": mustCookieStore(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			h, srv, results := newTestHandler(t, store)
+			srv.AddAuthorizationCode("auth-code", "777")
+
+			state, cookies := startLogin(t, h)
+			rec := callback(h, url.Values{"code": {"auth-code"}, "state": {state}}, cookies)
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("expected success, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			result := <-results
+			if result.token.UserID != "777" {
+				t.Errorf("expected token for user 777, got %q", result.token.UserID)
+			}
+			if time.Until(result.token.ExpiresAt) < 24*time.Hour {
+				t.Errorf("expected a long-lived token, expires at %s", result.token.ExpiresAt)
+			}
+			if len(result.scopes) == 0 {
+				t.Error("expected granted scopes")
+			}
+
+			if name == "cookie" {
+				return // stateless: replay protection comes from clearing the cookie
+			}
+			// A state can only be used once.
+			srv.AddAuthorizationCode("auth-code-2", "777")
+			rec = callback(h, url.Values{"code": {"auth-code-2"}, "state": {state}}, cookies)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected replayed state to be rejected, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_CallbackRejectsForeignState(t *testing.T) {
+	h, srv, results := newTestHandler(t, nil)
+	srv.AddAuthorizationCode("auth-code", "777")
+
+	_, cookies := startLogin(t, h)
+	otherState, _ := startLogin(t, h)
+
+	rec := callback(h, url.Values{"code": {"auth-code"}, "state": {otherState}}, cookies)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected state issued to another browser to be rejected, got %d", rec.Code)
+	}
+	rec = callback(h, url.Values{"code": {"auth-code"}, "state": {otherState}}, nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected callback without cookie to be rejected, got %d", rec.Code)
+	}
+	if len(results) != 0 {
+		t.Error("OnSuccess must not be called for a rejected callback")
+	}
+}
+
+func TestHandler_CallbackReportsDenial(t *testing.T) {
+	h, _, _ := newTestHandler(t, nil)
+	var got error
+	h.onError = func(w http.ResponseWriter, r *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	state, cookies := startLogin(t, h)
+	rec := callback(h, url.Values{
+		"state":             {state},
+		"error":             {"access_denied"},
+		"error_description": {"The user denied your request."},
+	}, cookies)
+	if rec.Code != http.StatusForbidden || !threads.IsAuthenticationError(got) {
+		t.Errorf("expected denial to reach OnError as an authentication error, got %d %v", rec.Code, got)
+	}
+}
+
+func TestStateStores_Expire(t *testing.T) {
+	now := time.Now()
+	memory := NewMemoryStateStore(time.Minute)
+	memory.now = func() time.Time { return now }
+	cookie := mustCookieStore(t)
+	cookie.now = func() time.Time { return now }
+
+	for name, store := range map[string]StateStore{"memory": memory, "cookie": cookie} {
+		rec := httptest.NewRecorder()
+		if err := store.Save(rec, httptest.NewRequest(http.MethodGet, "/login", nil), "abc"); err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/callback", nil)
+		for _, c := range rec.Result().Cookies() {
+			req.AddCookie(c)
+		}
+
+		now = now.Add(DefaultStateTTL + time.Minute)
+		if err := store.Consume(httptest.NewRecorder(), req, "abc"); err != ErrInvalidState {
+			t.Errorf("%s: expected expired state to be rejected, got %v", name, err)
+		}
+		now = time.Now()
+	}
+}
+
+func TestCookieStateStore_RejectsTampering(t *testing.T) {
+	store := mustCookieStore(t)
+	rec := httptest.NewRecorder()
+	if err := store.Save(rec, httptest.NewRequest(http.MethodGet, "/login", nil), "abc"); err != nil {
+		t.Fatal(err)
+	}
+	cookie := rec.Result().Cookies()[0]
+	if !cookie.HttpOnly {
+		t.Error("expected HttpOnly state cookie")
+	}
+
+	cookie.Value = "xyz" + cookie.Value[3:]
+	req := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	req.AddCookie(cookie)
+	if err := store.Consume(httptest.NewRecorder(), req, "xyz"); err != ErrInvalidState {
+		t.Errorf("expected tampered cookie to be rejected, got %v", err)
+	}
+
+	if _, err := NewCookieStateStore([]byte("short"), 0); err == nil {
+		t.Error("expected short secret to be rejected")
+	}
+}
+
+func mustCookieStore(t *testing.T) *CookieStateStore {
+	t.Helper()
+	store, err := NewCookieStateStore([]byte("0123456789abcdef0123456789abcdef"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
@@ -0,0 +1,220 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultStateTTL is how long a login may take before its state expires.
+const DefaultStateTTL = 10 * time.Minute
+
+// DefaultCookieName is the cookie used by the built-in state stores.
+const DefaultCookieName = "threads_oauth_state"
+
+// ErrInvalidState is returned when the callback's state parameter was not
+// issued to this browser, has already been used, or has expired.
+var ErrInvalidState = errors.New("oauth: invalid or expired state")
+
+// StateStore persists the OAuth state between the login redirect and the
+// callback. Implementations must bind the state to the browser that started
+// the login (typically with a cookie) so that a callback cannot be replayed
+// into another user's session.
+type StateStore interface {
+	// Save records state for the login started by r.
+	Save(w http.ResponseWriter, r *http.Request, state string) error
+
+	// Consume verifies that state was saved for the browser making r and has
+	// not expired, and invalidates it so it cannot be used again.
+	// It returns ErrInvalidState if verification fails.
+	Consume(w http.ResponseWriter, r *http.Request, state string) error
+}
+
+// CookieOptions controls the cookie written by the built-in state stores.
+type CookieOptions struct {
+	// Name of the cookie. Default: DefaultCookieName.
+	Name string
+
+	// Path of the cookie. Default: "/".
+	Path string
+
+	// Secure forces the Secure attribute. When false, it is set only for
+	// requests received over TLS; set it when running behind a TLS proxy.
+	Secure bool
+}
+
+func (o CookieOptions) set(w http.ResponseWriter, r *http.Request, value string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     o.name(),
+		Value:    value,
+		Path:     o.path(),
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   o.Secure || r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (o CookieOptions) clear(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     o.name(),
+		Path:     o.path(),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   o.Secure || r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (o CookieOptions) value(r *http.Request) string {
+	cookie, err := r.Cookie(o.name())
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func (o CookieOptions) name() string {
+	if o.Name == "" {
+		return DefaultCookieName
+	}
+	return o.Name
+}
+
+func (o CookieOptions) path() string {
+	if o.Path == "" {
+		return "/"
+	}
+	return o.Path
+}
+
+// MemoryStateStore keeps issued states in memory with a TTL and binds each
+// one to the browser with a cookie. Each state can be consumed once. It is
+// suitable for single-instance deployments; use CookieStateStore when
+// callbacks may reach a different instance than the login.
+type MemoryStateStore struct {
+	Cookie CookieOptions
+
+	ttl    time.Duration
+	now    func() time.Time
+	mu     sync.Mutex
+	states map[string]time.Time
+}
+
+// NewMemoryStateStore creates an in-memory store. A non-positive ttl uses
+// DefaultStateTTL.
+func NewMemoryStateStore(ttl time.Duration) *MemoryStateStore {
+	if ttl <= 0 {
+		ttl = DefaultStateTTL
+	}
+	return &MemoryStateStore{ttl: ttl, now: time.Now, states: make(map[string]time.Time)}
+}
+
+// Save records state and sets the browser cookie
+func (m *MemoryStateStore) Save(w http.ResponseWriter, r *http.Request, state string) error {
+	now := m.now()
+
+	m.mu.Lock()
+	for s, expiresAt := range m.states {
+		if !now.Before(expiresAt) {
+			delete(m.states, s)
+		}
+	}
+	m.states[state] = now.Add(m.ttl)
+	m.mu.Unlock()
+
+	m.Cookie.set(w, r, state, m.ttl)
+	return nil
+}
+
+// Consume checks state against the browser cookie and the stored entry
+func (m *MemoryStateStore) Consume(w http.ResponseWriter, r *http.Request, state string) error {
+	m.Cookie.clear(w, r)
+
+	cookie := m.Cookie.value(r)
+	if state == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		return ErrInvalidState
+	}
+
+	m.mu.Lock()
+	expiresAt, ok := m.states[state]
+	delete(m.states, state)
+	m.mu.Unlock()
+
+	if !ok || !m.now().Before(expiresAt) {
+		return ErrInvalidState
+	}
+	return nil
+}
+
+// CookieStateStore keeps the state only in an HMAC-signed cookie, so no
+// server-side storage is shared between instances. The cookie is cleared
+// when consumed; because nothing is recorded server-side, a copy of the
+// cookie remains valid until it expires, which is harmless since the
+// authorization code it protects can only be exchanged once.
+type CookieStateStore struct {
+	Cookie CookieOptions
+
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCookieStateStore creates a signed-cookie store. secret must be at least
+// 32 bytes and the same on every instance. A non-positive ttl uses
+// DefaultStateTTL.
+func NewCookieStateStore(secret []byte, ttl time.Duration) (*CookieStateStore, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("oauth: cookie secret must be at least 32 bytes")
+	}
+	if ttl <= 0 {
+		ttl = DefaultStateTTL
+	}
+	return &CookieStateStore{secret: append([]byte(nil), secret...), ttl: ttl, now: time.Now}, nil
+}
+
+// Save writes the signed state cookie
+func (c *CookieStateStore) Save(w http.ResponseWriter, r *http.Request, state string) error {
+	if strings.Contains(state, ".") {
+		return fmt.Errorf("oauth: state must not contain '.'")
+	}
+	payload := state + "." + strconv.FormatInt(c.now().Add(c.ttl).Unix(), 10)
+	c.Cookie.set(w, r, payload+"."+c.sign(payload), c.ttl)
+	return nil
+}
+
+// Consume verifies the signed cookie matches state and has not expired
+func (c *CookieStateStore) Consume(w http.ResponseWriter, r *http.Request, state string) error {
+	c.Cookie.clear(w, r)
+
+	parts := strings.Split(c.Cookie.value(r), ".")
+	if state == "" || len(parts) != 3 {
+		return ErrInvalidState
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(c.sign(payload))) {
+		return ErrInvalidState
+	}
+	if subtle.ConstantTimeCompare([]byte(parts[0]), []byte(state)) != 1 {
+		return ErrInvalidState
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !c.now().Before(time.Unix(expiresAt, 0)) {
+		return ErrInvalidState
+	}
+	return nil
+}
+
+func (c *CookieStateStore) sign(payload string) string {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,214 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostDraft is one part of a thread chain. It is implemented by
+// *TextPostContent, *ImagePostContent, *VideoPostContent and
+// *CarouselPostContent, so parts can mix text and media.
+type PostDraft interface {
+	isPostDraft()
+}
+
+func (*TextPostContent) isPostDraft()     {}
+func (*ImagePostContent) isPostDraft()    {}
+func (*VideoPostContent) isPostDraft()    {}
+func (*CarouselPostContent) isPostDraft() {}
+
+// ThreadChainOptions configures CreateThreadChain and ResumeThreadChain.
+type ThreadChainOptions struct {
+	// ReplyDelay is the minimum time between publishing a part and
+	// publishing the reply to it (default: ReplyPublishDelay). It is also
+	// waited before publishing the first part when its ReplyTo is set. Media
+	// parts are processed while waiting. Use a negative value to disable the
+	// delay.
+	ReplyDelay time.Duration
+
+	// RollbackOnFailure deletes the parts that were already published when a
+	// part fails, so that no partial chain is left behind.
+	RollbackOnFailure bool
+
+	// OnPartPublished is called after each part is published (optional).
+	OnPartPublished func(index int, post *Post)
+}
+
+// ThreadChainResult describes the progress of a thread chain. When a part
+// fails, pass the result to ResumeThreadChain to publish the remaining parts
+// or to RollbackThreadChain to delete the published ones.
+type ThreadChainResult struct {
+	// Posts holds the published parts in order; Posts[0] is the root post.
+	Posts []*Post
+
+	// FailedPart is the index of the part that failed, or -1.
+	FailedPart int
+
+	publishedAt time.Time // When the last part was published
+}
+
+// Complete reports whether all parts of a chain of total parts were published.
+func (r *ThreadChainResult) Complete(total int) bool {
+	return r.FailedPart < 0 && len(r.Posts) == total
+}
+
+// CreateThreadChain publishes parts as a thread: the first part as a post
+// and each following part as a reply to the previous one. All parts are
+// validated before anything is published. ReplyTo fields of all but the first
+// part are ignored; set the first part's ReplyTo to attach the whole chain
+// to an existing post.
+//
+// If a part fails, the returned error describes it and the result lists the
+// parts that were published; see ThreadChainOptions.RollbackOnFailure,
+// ResumeThreadChain and RollbackThreadChain. With WithIdempotencyKey, each
+// part uses a key derived from the chain's key, so retrying the whole chain
+// with the same key does not publish any part twice.
+func (c *Client) CreateThreadChain(ctx context.Context, parts []PostDraft, opts *ThreadChainOptions) (*ThreadChainResult, error) {
+	return c.ResumeThreadChain(ctx, &ThreadChainResult{FailedPart: -1}, parts, opts)
+}
+
+// ResumeThreadChain publishes the parts of a chain that come after the ones
+// recorded in result, replying to the last published post. parts must be
+// the full list of parts originally passed to CreateThreadChain. The result
+// is updated in place and returned.
+func (c *Client) ResumeThreadChain(ctx context.Context, result *ThreadChainResult, parts []PostDraft, opts *ThreadChainOptions) (*ThreadChainResult, error) {
+	if result == nil {
+		return nil, NewValidationError(400, "Thread chain result is required", "Pass the result returned by CreateThreadChain", "result")
+	}
+	if len(parts) == 0 {
+		return result, NewValidationError(400, "Thread chain parts are required", "A thread chain must have at least one part", "parts")
+	}
+	if len(result.Posts) > len(parts) {
+		return result, NewValidationError(400, "Thread chain result does not match parts",
+			fmt.Sprintf("%d parts published but only %d parts given", len(result.Posts), len(parts)), "parts")
+	}
+	if opts == nil {
+		opts = &ThreadChainOptions{}
+	}
+	delay := opts.ReplyDelay
+	if delay == 0 {
+		delay = ReplyPublishDelay
+	}
+
+	// Validate every remaining part before publishing anything
+	start := len(result.Posts)
+	for i := start; i < len(parts); i++ {
+		if err := c.checkChainPart(ctx, parts[i], i); err != nil {
+			result.FailedPart = i
+			return result, fmt.Errorf("thread chain part %d of %d is invalid: %w", i+1, len(parts), err)
+		}
+	}
+
+	result.FailedPart = -1
+	for i := start; i < len(parts); i++ {
+		post, err := c.publishChainPart(ctx, result, parts[i], i, delay)
+		if err != nil {
+			result.FailedPart = i
+			err = fmt.Errorf("thread chain part %d of %d failed: %w", i+1, len(parts), err)
+			if opts.RollbackOnFailure && len(result.Posts) > 0 {
+				if rollbackErr := c.RollbackThreadChain(ctx, result); rollbackErr != nil {
+					err = errors.Join(err, rollbackErr)
+				}
+			}
+			return result, err
+		}
+
+		result.Posts = append(result.Posts, post)
+		result.publishedAt = time.Now()
+		if opts.OnPartPublished != nil {
+			opts.OnPartPublished(i, post)
+		}
+	}
+
+	return result, nil
+}
+
+// RollbackThreadChain deletes the published parts of a chain, last part
+// first, and removes them from result. It stops at the first failure, so it
+// can be called again to finish the rollback.
+func (c *Client) RollbackThreadChain(ctx context.Context, result *ThreadChainResult) error {
+	if result == nil {
+		return NewValidationError(400, "Thread chain result is required", "Pass the result returned by CreateThreadChain", "result")
+	}
+
+	for i := len(result.Posts) - 1; i >= 0; i-- {
+		if _, err := c.DeletePost(ctx, ConvertToPostID(result.Posts[i].ID)); err != nil {
+			return fmt.Errorf("failed to roll back thread chain part %d: %w", i+1, err)
+		}
+		result.Posts = result.Posts[:i]
+	}
+	return nil
+}
+
+// checkChainPart validates one part of a chain without publishing it.
+func (c *Client) checkChainPart(ctx context.Context, part PostDraft, index int) error {
+	content, err := replyContent(part)
+	if err != nil {
+		return err
+	}
+
+	if text, ok := content.(*TextPostContent); ok {
+		if text.AutoPublishText {
+			return NewValidationError(400, "Auto-publish is not supported in thread chains", "Thread chain parts are published as containers", "auto_publish_text")
+		}
+		if text.IsGhostPost && index > 0 {
+			return NewValidationError(400, "Invalid ghost post", "Ghost posts cannot be replies", "is_ghost_post")
+		}
+	}
+
+	_, err = c.contentFlow(ctx, content)
+	return err
+}
+
+// publishChainPart publishes part index of a chain as a reply to the last
+// published part, at least delay after that part was published. A first part
+// that replies to an existing post is published at least delay after this
+// call, as a reply created with CreateReply would be.
+func (c *Client) publishChainPart(ctx context.Context, result *ThreadChainResult, part PostDraft, index int, delay time.Duration) (*Post, error) {
+	var content interface{} = part
+	if index > 0 {
+		content = withReplyTo(part, result.Posts[index-1].ID)
+	}
+
+	// Give each part its own idempotency key
+	if key, _ := ctx.Value(idempotencyKeyContextKey{}).(string); key != "" {
+		ctx = WithIdempotencyKey(ctx, fmt.Sprintf("%s/part-%d", key, index))
+	}
+
+	flow, err := c.contentFlow(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkQuota(ctx, publishQuota(content)); err != nil {
+		return nil, err
+	}
+
+	if delay > 0 && (index > 0 || strings.TrimSpace(replyTarget(content)) != "") {
+		publishedAt := result.publishedAt
+		if publishedAt.IsZero() {
+			// Resuming a chain from another process, or replying to an
+			// existing post; assume it was just published
+			publishedAt = time.Now()
+		}
+		notBefore := publishedAt.Add(delay)
+
+		flow.ready = func(ctx context.Context, containerID string) error {
+			if err := c.waitForPublishing(ctx, containerID); err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Until(notBefore)):
+				return nil
+			}
+		}
+	}
+
+	return c.createAndPublish(ctx, flow)
+}
@@ -0,0 +1,132 @@
+package threads
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCreateThreadChain_MixedMedia(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+
+	var published []int
+	result, err := client.CreateThreadChain(context.Background(), []PostDraft{
+		&TextPostContent{Text: "1/3 A thread"},
+		&ImagePostContent{ImageURL: "https://example.com/chart.png", Text: "2/3 The chart"},
+		&TextPostContent{Text: "3/3 Thanks for reading", ReplyTo: "ignored"},
+	}, &ThreadChainOptions{
+		ReplyDelay:      20 * time.Millisecond,
+		OnPartPublished: func(index int, post *Post) { published = append(published, index) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Complete(3) || len(published) != 3 {
+		t.Fatalf("expected all three parts to be published, got %+v and callbacks %v", result, published)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.replyTo["c1"] != "" || srv.replyTo["c2"] != "p-c1" || srv.replyTo["c3"] != "p-c2" {
+		t.Errorf("expected each part to reply to the previous one, got %v", srv.replyTo)
+	}
+}
+
+func TestCreateThreadChain_DelaysFirstPartReply(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	delay := 50 * time.Millisecond
+
+	start := time.Now()
+	result, err := client.CreateThreadChain(context.Background(), []PostDraft{
+		&TextPostContent{Text: "Replying with a thread", ReplyTo: "p-root"},
+	}, &ThreadChainOptions{ReplyDelay: delay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("expected the first part to wait %v before replying, took %v", delay, elapsed)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if !result.Complete(1) || srv.replyTo["c1"] != "p-root" {
+		t.Errorf("expected the first part to reply to p-root, got %+v and %v", result, srv.replyTo)
+	}
+}
+
+func TestCreateThreadChain_ResumeAndRollback(t *testing.T) {
+	srv := &publishServer{failCreate: 2}
+	client := publishTestClient(t, srv)
+	ctx := context.Background()
+	parts := []PostDraft{
+		&TextPostContent{Text: "root"},
+		&TextPostContent{Text: "middle"},
+		&VideoPostContent{VideoURL: "https://example.com/end.mp4"},
+	}
+	opts := &ThreadChainOptions{ReplyDelay: -1}
+
+	result, err := client.CreateThreadChain(ctx, parts, opts)
+	if err == nil {
+		t.Fatal("expected the second part to fail")
+	}
+	if result.FailedPart != 1 || len(result.Posts) != 1 || result.Posts[0].ID != "p-c1" {
+		t.Fatalf("expected only the root to be published, got %+v", result)
+	}
+
+	if _, err := client.ResumeThreadChain(ctx, result, parts, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Complete(3) || result.Posts[2].ID != "p-c4" {
+		t.Fatalf("expected the resumed chain to be complete, got %+v", result)
+	}
+	if creates, _ := srv.counts(); creates != 4 {
+		t.Errorf("expected the root not to be created again, got %d creates", creates)
+	}
+
+	if err := client.RollbackThreadChain(ctx, result); err != nil {
+		t.Fatal(err)
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(result.Posts) != 0 || len(srv.deleted) != 3 || srv.deleted[0] != "p-c4" || srv.deleted[2] != "p-c1" {
+		t.Errorf("expected all parts deleted last first, got %v", srv.deleted)
+	}
+}
+
+func TestCreateThreadChain_RollbackOnFailure(t *testing.T) {
+	srv := &publishServer{failCreate: 3}
+	client := publishTestClient(t, srv)
+
+	result, err := client.CreateThreadChain(context.Background(), []PostDraft{
+		&TextPostContent{Text: "one"},
+		&TextPostContent{Text: "two"},
+		&TextPostContent{Text: "three"},
+	}, &ThreadChainOptions{ReplyDelay: -1, RollbackOnFailure: true})
+	if err == nil {
+		t.Fatal("expected the third part to fail")
+	}
+	if result.FailedPart != 2 || len(result.Posts) != 0 {
+		t.Errorf("expected the published parts to be rolled back, got %+v", result)
+	}
+	if len(srv.deleted) != 2 {
+		t.Errorf("expected two deletions, got %v", srv.deleted)
+	}
+}
+
+func TestCreateThreadChain_ValidatesAllPartsFirst(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+
+	result, err := client.CreateThreadChain(context.Background(), []PostDraft{
+		&TextPostContent{Text: "fine"},
+		&ImagePostContent{},
+	}, nil)
+	if !IsValidationError(err) || result.FailedPart != 1 {
+		t.Fatalf("expected a ValidationError for the second part, got %v", err)
+	}
+	if creates, _ := srv.counts(); creates != 0 {
+		t.Errorf("expected nothing to be published, got %d creates", creates)
+	}
+}
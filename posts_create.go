@@ -7,196 +7,102 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 )
 
 // CreateTextPost creates a new text post on Threads
 func (c *Client) CreateTextPost(ctx context.Context, content *TextPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateTextPostContent(content); err != nil {
+	if err := c.checkTextPost(ctx, content); err != nil {
 		return nil, err
 	}
 
-	if strings.TrimSpace(content.Text) == "" {
-		return nil, NewValidationError(400, "Text content is required", ErrEmptyPostID, "text")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	// Fail fast if the quota guard knows the post or reply quota is used up
+	if err := c.checkQuota(ctx, publishQuota(content)); err != nil {
 		return nil, err
 	}
 
 	// Handle auto_publish_text flow differently
 	if content.AutoPublishText {
-		return c.createAndPublishTextPostDirectly(ctx, content)
+		return c.publishIdempotent(ctx, func(*IdempotencyRecord) (PostID, error) {
+			return c.createAndPublishTextPostDirectly(ctx, content)
+		})
 	}
 
 	// Standard container creation and publishing flow
-	containerID, err := c.createTextContainer(ctx, content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create text container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if err := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); err != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", err)
-	}
-
-	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to publish text post: %w", err)
-	}
-
-	return post, nil
+	return c.createAndPublish(ctx, publishFlow{
+		container: "text container",
+		post:      "text post",
+		create: func(ctx context.Context) (string, error) {
+			return c.createTextContainer(ctx, content)
+		},
+		ready: c.waitForPublishing,
+	})
 }
 
 // CreateImagePost creates a new image post on Threads
 func (c *Client) CreateImagePost(ctx context.Context, content *ImagePostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateImagePostContent(content); err != nil {
+	if err := c.checkImagePost(ctx, content); err != nil {
 		return nil, err
 	}
 
-	if strings.TrimSpace(content.ImageURL) == "" {
-		return nil, NewValidationError(400, "Image URL is required", "Post must have an image URL", "image_url")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	// Fail fast if the quota guard knows the post or reply quota is used up
+	if err := c.checkQuota(ctx, publishQuota(content)); err != nil {
 		return nil, err
 	}
 
-	// Create container first
-	containerID, err := c.createImageContainer(ctx, content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create image container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if err := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); err != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", err)
-	}
-
-	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to publish image post: %w", err)
-	}
-
-	return post, nil
+	// Create the container, wait for it to be ready and publish it
+	return c.createAndPublish(ctx, publishFlow{
+		container: "image container",
+		post:      "image post",
+		create: func(ctx context.Context) (string, error) {
+			return c.createImageContainer(ctx, content)
+		},
+		ready: c.waitForPublishing,
+	})
 }
 
 // CreateVideoPost creates a new video post on Threads
 func (c *Client) CreateVideoPost(ctx context.Context, content *VideoPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateVideoPostContent(content); err != nil {
+	if err := c.checkVideoPost(ctx, content); err != nil {
 		return nil, err
 	}
 
-	if strings.TrimSpace(content.VideoURL) == "" {
-		return nil, NewValidationError(400, "Video URL is required", "Post must have a video URL", "video_url")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	// Fail fast if the quota guard knows the post or reply quota is used up
+	if err := c.checkQuota(ctx, publishQuota(content)); err != nil {
 		return nil, err
 	}
 
-	// Create container first
-	containerID, err := c.createVideoContainer(ctx, content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create video container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if err := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); err != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", err)
-	}
-
-	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to publish video post: %w", err)
-	}
-
-	return post, nil
+	// Create the container, wait for it to be ready and publish it
+	return c.createAndPublish(ctx, publishFlow{
+		container: "video container",
+		post:      "video post",
+		create: func(ctx context.Context) (string, error) {
+			return c.createVideoContainer(ctx, content)
+		},
+		ready: c.waitForPublishing,
+	})
 }
 
 // CreateCarouselPost creates a new carousel post on Threads
 func (c *Client) CreateCarouselPost(ctx context.Context, content *CarouselPostContent) (*Post, error) {
-	// Validate content according to API limits
-	if err := c.ValidateCarouselPostContent(content); err != nil {
+	if err := c.checkCarouselPost(ctx, content); err != nil {
 		return nil, err
 	}
 
-	if len(content.Children) == 0 {
-		return nil, NewValidationError(400, "Children containers are required", "Carousel post must have at least one child container", "children")
-	}
-
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	// Fail fast if the quota guard knows the post or reply quota is used up
+	if err := c.checkQuota(ctx, publishQuota(content)); err != nil {
 		return nil, err
 	}
 
-	// Wait for all child containers to be ready in parallel
-	// The Threads API requires child containers to be in FINISHED status
-	type childResult struct {
-		index int
-		id    string
-		err   error
-	}
-	results := make(chan childResult, len(content.Children))
-	childCtx, cancelChildren := context.WithCancel(ctx)
-	defer cancelChildren()
-
-	for i, childID := range content.Children {
-		go func(idx int, cID string) {
-			err := c.waitForContainerReady(childCtx, ContainerID(cID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval)
-			results <- childResult{index: idx, id: cID, err: err}
-		}(i, childID)
-	}
-
-	// Collect all results; cancel siblings as soon as any failure is seen
-	errs := make([]error, len(content.Children))
-	for range content.Children {
-		result := <-results
-		errs[result.index] = result.err
-		if result.err != nil {
-			cancelChildren()
-		}
-	}
-	// Report the first real failure, skipping cancellation side-effects from siblings
-	for i, err := range errs {
-		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("child container %d (%s) not ready: %w", i+1, content.Children[i], err)
-		}
-	}
-	// Fallback: if only cancellation errors exist, report the first one
-	for i, err := range errs {
-		if err != nil {
-			return nil, fmt.Errorf("child container %d (%s) not ready: %w", i+1, content.Children[i], err)
-		}
-	}
-
-	// Create container first
-	containerID, err := c.createCarouselContainer(ctx, content)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create carousel container: %w", err)
-	}
-
-	// Wait for container to be ready
-	if err := c.waitForContainerReady(ctx, ContainerID(containerID), DefaultContainerPollMaxAttempts, DefaultContainerPollInterval); err != nil {
-		return nil, fmt.Errorf("container not ready for publishing: %w", err)
-	}
-
-	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to publish carousel post: %w", err)
-	}
-
-	return post, nil
+	// Create the item containers and the carousel container, wait for it
+	// to be ready and publish it
+	return c.createAndPublish(ctx, publishFlow{
+		container: "carousel container",
+		post:      "carousel post",
+		create: func(ctx context.Context) (string, error) {
+			return c.createCarouselPostContainer(ctx, content)
+		},
+		ready: c.waitForPublishing,
+	})
 }
 
 // CreateQuotePost creates a new quote post on Threads
@@ -254,7 +160,7 @@ func (c *Client) RepostPost(ctx context.Context, postID PostID) (*Post, error) {
 
 	// Use the direct repost endpoint
 	path := fmt.Sprintf("/%s/repost", postID.String())
-	resp, err := c.httpClient.POST(path, nil, c.getAccessTokenSafe())
+	resp, err := c.httpClient.POSTWithContext(ctx, path, nil, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repost: %w", err)
 	}
@@ -325,6 +231,162 @@ func (c *Client) CreateMediaContainer(ctx context.Context, mediaType, mediaURL,
 	return ConvertToContainerID(containerID), nil
 }
 
+// checkTextPost validates text post content and ensures a usable token.
+func (c *Client) checkTextPost(ctx context.Context, content *TextPostContent) error {
+	// Validate content according to API limits
+	if err := c.ValidateTextPostContent(content); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(content.Text) == "" {
+		return NewValidationError(400, "Text content is required", ErrEmptyPostID, "text")
+	}
+
+	// Ensure we have a valid token
+	return c.EnsureValidToken(ctx)
+}
+
+// checkImagePost validates image post content and ensures a usable token.
+func (c *Client) checkImagePost(ctx context.Context, content *ImagePostContent) error {
+	// Validate content according to API limits
+	if err := c.ValidateImagePostContent(content); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(content.ImageURL) == "" && !hasLocalMedia(content.ImagePath, content.ImageReader) {
+		return NewValidationError(400, "Image URL is required", "Post must have an image URL", "image_url")
+	}
+
+	// Ensure we have a valid token
+	return c.EnsureValidToken(ctx)
+}
+
+// checkVideoPost validates video post content and ensures a usable token.
+func (c *Client) checkVideoPost(ctx context.Context, content *VideoPostContent) error {
+	// Validate content according to API limits
+	if err := c.ValidateVideoPostContent(content); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(content.VideoURL) == "" && !hasLocalMedia(content.VideoPath, content.VideoReader) {
+		return NewValidationError(400, "Video URL is required", "Post must have a video URL", "video_url")
+	}
+
+	// Ensure we have a valid token
+	return c.EnsureValidToken(ctx)
+}
+
+// checkCarouselPost validates carousel post content and ensures a usable token.
+func (c *Client) checkCarouselPost(ctx context.Context, content *CarouselPostContent) error {
+	// Validate content according to API limits
+	if err := c.ValidateCarouselPostContent(content); err != nil {
+		return err
+	}
+
+	if len(content.Children)+len(content.Items) == 0 {
+		return NewValidationError(400, "Children containers are required", "Carousel post must have at least one child container", "children")
+	}
+
+	// Ensure we have a valid token
+	return c.EnsureValidToken(ctx)
+}
+
+// contentFlow validates text, image, video or carousel content, ensures a
+// usable token and returns the flow that creates and publishes it. Carousel
+// flows wait for their item containers before creating the carousel.
+func (c *Client) contentFlow(ctx context.Context, content interface{}) (publishFlow, error) {
+	flow := publishFlow{ready: c.waitForPublishing}
+
+	switch v := content.(type) {
+	case *TextPostContent:
+		if err := c.checkTextPost(ctx, v); err != nil {
+			return flow, err
+		}
+		flow.container, flow.post = "text container", "text post"
+		flow.create = func(ctx context.Context) (string, error) {
+			return c.createTextContainer(ctx, v)
+		}
+
+	case *ImagePostContent:
+		if err := c.checkImagePost(ctx, v); err != nil {
+			return flow, err
+		}
+		flow.container, flow.post = "image container", "image post"
+		flow.create = func(ctx context.Context) (string, error) {
+			return c.createImageContainer(ctx, v)
+		}
+
+	case *VideoPostContent:
+		if err := c.checkVideoPost(ctx, v); err != nil {
+			return flow, err
+		}
+		flow.container, flow.post = "video container", "video post"
+		flow.create = func(ctx context.Context) (string, error) {
+			return c.createVideoContainer(ctx, v)
+		}
+
+	case *CarouselPostContent:
+		if err := c.checkCarouselPost(ctx, v); err != nil {
+			return flow, err
+		}
+		flow.container, flow.post = "carousel container", "carousel post"
+		flow.create = func(ctx context.Context) (string, error) {
+			return c.createCarouselPostContainer(ctx, v)
+		}
+
+	default:
+		return flow, NewValidationError(400, "Unsupported content type",
+			fmt.Sprintf("unsupported content type: %T", content), "content")
+	}
+
+	return flow, nil
+}
+
+// waitForCarouselChildren waits in parallel for all carousel item containers
+// to be ready and reports the first failure.
+func (c *Client) waitForCarouselChildren(ctx context.Context, children []string) error {
+	type childResult struct {
+		index int
+		id    string
+		err   error
+	}
+	results := make(chan childResult, len(children))
+	childCtx, cancelChildren := context.WithCancel(ctx)
+	defer cancelChildren()
+
+	poll := c.containerPollConfigFor(ctx)
+	for i, childID := range children {
+		go func(idx int, cID string) {
+			err := c.waitForContainerReady(childCtx, ContainerID(cID), poll)
+			results <- childResult{index: idx, id: cID, err: err}
+		}(i, childID)
+	}
+
+	// Collect all results; cancel siblings as soon as any failure is seen
+	errs := make([]error, len(children))
+	for range children {
+		result := <-results
+		errs[result.index] = result.err
+		if result.err != nil {
+			cancelChildren()
+		}
+	}
+	// Report the first real failure, skipping cancellation side-effects from siblings
+	for i, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("child container %d (%s) not ready: %w", i+1, children[i], err)
+		}
+	}
+	// Fallback: if only cancellation errors exist, report the first one
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("child container %d (%s) not ready: %w", i+1, children[i], err)
+		}
+	}
+
+	return nil
+}
+
 // createTextContainer creates a container for text content
 func (c *Client) createTextContainer(ctx context.Context, content *TextPostContent) (string, error) {
 	builder := NewContainerBuilder().
@@ -351,11 +413,20 @@ func (c *Client) createTextContainer(ctx context.Context, content *TextPostConte
 	return c.createContainer(ctx, builder.Build())
 }
 
-// createImageContainer creates a container for image content
+// createImageContainer creates a container for image content, uploading a
+// local image to the MediaHost first
 func (c *Client) createImageContainer(ctx context.Context, content *ImagePostContent) (string, error) {
+	return c.createHostedContainer(ctx, MediaTypeImage, content.ImageURL, content.ImagePath, content.ImageReader, func(imageURL string) (string, error) {
+		return c.createImageContainerFromURL(ctx, content, imageURL)
+	})
+}
+
+// createImageContainerFromURL creates a container for image content with
+// the image at imageURL
+func (c *Client) createImageContainerFromURL(ctx context.Context, content *ImagePostContent, imageURL string) (string, error) {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeImage).
-		SetImageURL(content.ImageURL).
+		SetImageURL(imageURL).
 		SetText(content.Text).
 		SetAltText(content.AltText).
 		SetReplyControl(content.ReplyControl).
@@ -375,11 +446,20 @@ func (c *Client) createImageContainer(ctx context.Context, content *ImagePostCon
 	return c.createContainer(ctx, builder.Build())
 }
 
-// createVideoContainer creates a container for video content
+// createVideoContainer creates a container for video content, uploading a
+// local video to the MediaHost first
 func (c *Client) createVideoContainer(ctx context.Context, content *VideoPostContent) (string, error) {
+	return c.createHostedContainer(ctx, MediaTypeVideo, content.VideoURL, content.VideoPath, content.VideoReader, func(videoURL string) (string, error) {
+		return c.createVideoContainerFromURL(ctx, content, videoURL)
+	})
+}
+
+// createVideoContainerFromURL creates a container for video content with
+// the video at videoURL
+func (c *Client) createVideoContainerFromURL(ctx context.Context, content *VideoPostContent, videoURL string) (string, error) {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeVideo).
-		SetVideoURL(content.VideoURL).
+		SetVideoURL(videoURL).
 		SetText(content.Text).
 		SetAltText(content.AltText).
 		SetReplyControl(content.ReplyControl).
@@ -428,7 +508,9 @@ func (c *Client) createCarouselContainer(ctx context.Context, content *CarouselP
 }
 
 // createAndPublishTextPostDirectly creates and publishes a text post directly when auto_publish_text is true
-func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *TextPostContent) (*Post, error) {
+// and returns the ID of the new post. With no container to inspect, failures that may have published the
+// post are not retried.
+func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *TextPostContent) (PostID, error) {
 	builder := NewContainerBuilder().
 		SetMediaType(MediaTypeText).
 		SetText(content.Text).
@@ -449,37 +531,40 @@ func (c *Client) createAndPublishTextPostDirectly(ctx context.Context, content *
 	// Get user ID from token info
 	userID := c.getUserID()
 	if userID == "" {
-		return nil, NewAuthenticationError(401, "User ID not available", "Cannot determine user ID from token")
+		return "", NewAuthenticationError(401, "User ID not available", "Cannot determine user ID from token")
 	}
 
 	// Make API call to create and publish post directly
 	path := fmt.Sprintf("/%s/threads", userID)
-	resp, err := c.httpClient.POST(path, builder.Build(), c.getAccessTokenSafe())
+	resp, err := c.postPublishing(ctx, path, builder.Build(), nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, c.handleAPIError(resp)
+		return "", c.handleAPIError(resp)
 	}
 
 	// Parse response - when auto_publish_text is true, the API returns the post ID directly
 	var post Post
 	if err := safeJSONUnmarshal(resp.Body, &post, "direct publish response", resp.RequestID); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// Validate that we got a valid post ID
 	if post.ID == "" {
-		return nil, NewAPIError(resp.StatusCode, "Post ID not returned", "API response missing post ID", resp.RequestID)
+		return "", NewAPIError(resp.StatusCode, "Post ID not returned", "API response missing post ID", resp.RequestID)
 	}
 
-	// Fetch the created post details
-	return c.GetPost(ctx, ConvertToPostID(post.ID))
+	return ConvertToPostID(post.ID), nil
 }
 
-// createContainer is a helper method to create containers with given parameters
-func (c *Client) createContainer(_ context.Context, params url.Values) (string, error) {
+// createContainer is a helper method to create containers with given parameters.
+// With an idempotency key, failures that may have created the container anyway
+// are not retried, so that a retry by the caller resumes from the recorded
+// container. Without one, a second container left by a lost response is never
+// published and simply expires.
+func (c *Client) createContainer(ctx context.Context, params url.Values) (string, error) {
 	// Get user ID from token info
 	userID := c.getUserID()
 	if userID == "" {
@@ -488,7 +573,13 @@ func (c *Client) createContainer(_ context.Context, params url.Values) (string,
 
 	// Make API call to create container
 	path := fmt.Sprintf("/%s/threads", userID)
-	resp, err := c.httpClient.POST(path, params, c.getAccessTokenSafe())
+	var resp *Response
+	var err error
+	if key, _ := ctx.Value(idempotencyKeyContextKey{}).(string); key != "" {
+		resp, err = c.postPublishing(ctx, path, params, nil)
+	} else {
+		resp, err = c.httpClient.POSTWithContext(ctx, path, params, c.getAccessTokenSafe())
+	}
 	if err != nil {
 		return "", err
 	}
@@ -515,14 +606,27 @@ func (c *Client) createContainer(_ context.Context, params url.Values) (string,
 
 // publishContainer publishes a created container
 func (c *Client) publishContainer(ctx context.Context, containerID string) (*Post, error) {
+	postID, err := c.publishContainerID(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the created post details
+	return c.GetPost(ctx, postID)
+}
+
+// publishContainerID publishes a created container and returns the new post's ID.
+// A failed attempt is only retried once the container's status confirms it was
+// not published, so retries never publish the same container twice.
+func (c *Client) publishContainerID(ctx context.Context, containerID string) (PostID, error) {
 	if containerID == "" {
-		return nil, NewValidationError(400, ErrEmptyContainerID, "Cannot publish without container ID", "container_id")
+		return "", NewValidationError(400, ErrEmptyContainerID, "Cannot publish without container ID", "container_id")
 	}
 
 	// Get user ID from token info
 	userID := c.getUserID()
 	if userID == "" {
-		return nil, NewAuthenticationError(401, "User ID not available", "Cannot determine user ID from token")
+		return "", NewAuthenticationError(401, "User ID not available", "Cannot determine user ID from token")
 	}
 
 	// Build request parameters
@@ -532,13 +636,22 @@ func (c *Client) publishContainer(ctx context.Context, containerID string) (*Pos
 
 	// Make API call to publish container
 	path := fmt.Sprintf("/%s/threads_publish", userID)
-	resp, err := c.httpClient.POST(path, params, c.getAccessTokenSafe())
+	resp, err := c.postPublishing(ctx, path, params, func(ctx context.Context) error {
+		status, err := c.GetContainerStatus(ctx, ContainerID(containerID))
+		if err != nil {
+			return fmt.Errorf("publish outcome unknown: %w", err)
+		}
+		if status.Status == ContainerStatusPublished {
+			return NewAlreadyPublishedError(ContainerID(containerID))
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, c.handleAPIError(resp)
+		return "", c.handleAPIError(resp)
 	}
 
 	// Parse response to get post ID
@@ -547,15 +660,14 @@ func (c *Client) publishContainer(ctx context.Context, containerID string) (*Pos
 	}
 
 	if err := json.Unmarshal(resp.Body, &publishResp); err != nil {
-		return nil, NewAPIError(resp.StatusCode, "Failed to parse publish response", err.Error(), resp.RequestID)
+		return "", NewAPIError(resp.StatusCode, "Failed to parse publish response", err.Error(), resp.RequestID)
 	}
 
 	if publishResp.ID == "" {
-		return nil, NewAPIError(resp.StatusCode, "Post ID not returned", "API response missing post ID", resp.RequestID)
+		return "", NewAPIError(resp.StatusCode, "Post ID not returned", "API response missing post ID", resp.RequestID)
 	}
 
-	// Fetch the created post details
-	return c.GetPost(ctx, ConvertToPostID(publishResp.ID))
+	return ConvertToPostID(publishResp.ID), nil
 }
 
 // GetContainerStatus retrieves the status of a media container
@@ -564,6 +676,8 @@ func (c *Client) publishContainer(ctx context.Context, containerID string) (*Pos
 // - ID: The container ID
 // - Status: Current status (IN_PROGRESS, FINISHED, PUBLISHED, ERROR, EXPIRED)
 // - ErrorMessage: Error details if status is ERROR
+// Use ContainerStatus.Err to turn a failed or expired status into a typed
+// *ContainerProcessingError or *ContainerError.
 func (c *Client) GetContainerStatus(ctx context.Context, containerID ContainerID) (*ContainerStatus, error) {
 	if !containerID.Valid() {
 		return nil, NewValidationError(400, ErrEmptyContainerID, "Cannot check status without container ID", "container_id")
@@ -581,7 +695,7 @@ func (c *Client) GetContainerStatus(ctx context.Context, containerID ContainerID
 
 	// Make API call to get container status
 	path := fmt.Sprintf("/%s", containerID.String())
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	resp, err := c.httpClient.GETWithContext(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container status: %w", err)
 	}
@@ -605,38 +719,19 @@ func (c *Client) GetContainerStatus(ctx context.Context, containerID ContainerID
 		return nil, NewAPIError(resp.StatusCode, "Container status not returned", "API response missing container status", resp.RequestID)
 	}
 
+	// Media hosted for the container is no longer needed once it is processed
+	if status.Status != ContainerStatusInProgress {
+		c.releaseHostedMedia(containerID.String())
+	}
+
 	return &status, nil
 }
 
-// waitForContainerReady polls the container status until it's ready to be published
-// Returns an error if the container fails or times out. Respects context cancellation
-// by using select with ctx.Done() instead of bare time.Sleep.
-func (c *Client) waitForContainerReady(ctx context.Context, containerID ContainerID, maxAttempts int, pollInterval time.Duration) error {
-	for attempt := 0; attempt < maxAttempts; attempt++ {
-		status, err := c.GetContainerStatus(ctx, containerID)
-		if err != nil {
-			return fmt.Errorf("failed to check container status: %w", err)
-		}
-
-		switch status.Status {
-		case ContainerStatusFinished:
-			return nil
-		case ContainerStatusError:
-			if status.ErrorMessage != "" {
-				return fmt.Errorf("container processing failed: %s", status.ErrorMessage)
-			}
-			return fmt.Errorf("container processing failed with error status")
-		case ContainerStatusExpired:
-			return fmt.Errorf("container expired before it could be published")
-		default:
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(pollInterval):
-				continue
-			}
-		}
+// waitForPublishing waits with the polling policy for ctx until a container
+// can be published.
+func (c *Client) waitForPublishing(ctx context.Context, containerID string) error {
+	if err := c.waitForContainerReady(ctx, ContainerID(containerID), c.containerPollConfigFor(ctx)); err != nil {
+		return fmt.Errorf("container not ready for publishing: %w", err)
 	}
-
-	return fmt.Errorf("timeout waiting for container to be ready after %d attempts", maxAttempts)
+	return nil
 }
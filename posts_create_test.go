@@ -2,6 +2,7 @@ package threads
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"sync/atomic"
@@ -715,7 +716,7 @@ func TestCreateMediaContainer_NotAuthenticated(t *testing.T) {
 func TestWaitForContainerReady_Finished(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"FINISHED"}`))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 3, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 3, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -724,7 +725,7 @@ func TestWaitForContainerReady_Finished(t *testing.T) {
 func TestWaitForContainerReady_Error(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"ERROR","error_message":"upload failed"}`))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 3, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 3, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("expected error for container error status")
 	}
@@ -736,19 +737,20 @@ func TestWaitForContainerReady_Error(t *testing.T) {
 func TestWaitForContainerReady_ErrorNoMessage(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"ERROR"}`))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 3, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 3, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("expected error for container error status")
 	}
-	if !strings.Contains(err.Error(), "container processing failed with error status") {
-		t.Errorf("expected generic error message, got: %v", err)
+	var containerErr *ContainerError
+	if !errors.As(err, &containerErr) || containerErr.ErrorCode != ContainerErrUnknown {
+		t.Errorf("expected ContainerError with unknown code, got: %v", err)
 	}
 }
 
 func TestWaitForContainerReady_Expired(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"EXPIRED"}`))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 3, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 3, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("expected error for expired container")
 	}
@@ -760,7 +762,7 @@ func TestWaitForContainerReady_Expired(t *testing.T) {
 func TestWaitForContainerReady_Timeout(t *testing.T) {
 	client := testClient(t, jsonHandler(200, `{"id":"c1","status":"IN_PROGRESS"}`))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 2, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 2, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("expected timeout error")
 	}
@@ -775,7 +777,7 @@ func TestWaitForContainerReady_ContextCancelled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // cancel immediately
 
-	err := client.waitForContainerReady(ctx, ConvertToContainerID("c1"), 10, 10*time.Millisecond)
+	err := client.waitForContainerReady(ctx, ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 10, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err == nil {
 		t.Fatal("expected error for cancelled context")
 	}
@@ -796,7 +798,7 @@ func TestWaitForContainerReady_ProgressThenFinished(t *testing.T) {
 
 	client := testClient(t, http.HandlerFunc(handler))
 
-	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), 5, 10*time.Millisecond)
+	err := client.waitForContainerReady(context.Background(), ConvertToContainerID("c1"), ContainerPollConfig{MaxAttempts: 5, InitialInterval: 10 * time.Millisecond, BackoffFactor: 1})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
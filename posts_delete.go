@@ -23,6 +23,11 @@ func (c *Client) DeletePost(ctx context.Context, postID PostID) (string, error)
 		return "", err
 	}
 
+	// Fail fast if the quota guard knows the delete quota is used up
+	if err := c.checkQuota(ctx, EndpointDelete); err != nil {
+		return "", err
+	}
+
 	// First, validate that the post exists and is owned by the authenticated user
 	if err := c.validatePostOwnership(ctx, postID); err != nil {
 		return "", err
@@ -30,7 +35,7 @@ func (c *Client) DeletePost(ctx context.Context, postID PostID) (string, error)
 
 	// Make API call to delete post
 	path := fmt.Sprintf("/%s", postID.String())
-	resp, err := c.httpClient.DELETE(path, c.getAccessTokenSafe())
+	resp, err := c.httpClient.DELETEWithContext(ctx, path, c.getAccessTokenSafe())
 	if err != nil {
 		return "", err
 	}
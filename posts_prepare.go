@@ -0,0 +1,203 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// containerLifetime is how long a container can be published after it is
+// created; containers that are not published within 24 hours expire.
+const containerLifetime = 24 * time.Hour
+
+// preparedContainer describes a container created by a Prepare*Post method.
+type preparedContainer struct {
+	quota     EndpointClass // Quota checked when it is published
+	textReply bool          // Published no sooner than ReplyPublishDelay after created
+	created   time.Time
+}
+
+// PrepareTextPost creates a text post container without publishing it, so
+// it can be reviewed and published later with PublishContainer. Containers
+// expire if they are not published within 24 hours. AutoPublishText is not
+// supported because it publishes immediately. As with CreateReply, a text
+// reply is published no sooner than ReplyPublishDelay after it was prepared.
+func (c *Client) PrepareTextPost(ctx context.Context, content *TextPostContent) (ContainerID, error) {
+	if err := c.checkTextPost(ctx, content); err != nil {
+		return "", err
+	}
+
+	if content.AutoPublishText {
+		return "", NewValidationError(400, "Auto-publish is not supported for prepared posts", "Publish prepared containers with PublishContainer", "auto_publish_text")
+	}
+
+	containerID, err := c.createTextContainer(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to create text container: %w", err)
+	}
+
+	c.trackPrepared(containerID, content)
+	return ConvertToContainerID(containerID), nil
+}
+
+// PrepareImagePost creates an image post container without publishing it.
+// Use WaitForContainer to confirm the image was processed and
+// PublishContainer to publish it.
+func (c *Client) PrepareImagePost(ctx context.Context, content *ImagePostContent) (ContainerID, error) {
+	if err := c.checkImagePost(ctx, content); err != nil {
+		return "", err
+	}
+
+	containerID, err := c.createImageContainer(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to create image container: %w", err)
+	}
+
+	c.trackPrepared(containerID, content)
+	return ConvertToContainerID(containerID), nil
+}
+
+// PrepareVideoPost creates a video post container without publishing it.
+// Video processing can take minutes; use WaitForContainer to confirm it
+// finished and PublishContainer to publish it.
+func (c *Client) PrepareVideoPost(ctx context.Context, content *VideoPostContent) (ContainerID, error) {
+	if err := c.checkVideoPost(ctx, content); err != nil {
+		return "", err
+	}
+
+	containerID, err := c.createVideoContainer(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to create video container: %w", err)
+	}
+
+	c.trackPrepared(containerID, content)
+	return ConvertToContainerID(containerID), nil
+}
+
+// PrepareCarouselPost creates containers for the carousel's Items, waits
+// for the carousel item containers to finish processing and creates the
+// carousel container without publishing it.
+func (c *Client) PrepareCarouselPost(ctx context.Context, content *CarouselPostContent) (ContainerID, error) {
+	if err := c.checkCarouselPost(ctx, content); err != nil {
+		return "", err
+	}
+
+	containerID, err := c.createCarouselPostContainer(ctx, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to create carousel container: %w", err)
+	}
+
+	c.trackPrepared(containerID, content)
+	return ConvertToContainerID(containerID), nil
+}
+
+// WaitForContainer polls a container until it is FINISHED and can be
+// published, following Config.ContainerPolling or WithContainerPolling.
+// It returns a *ContainerError if processing fails, the container expired
+// or polling times out, and an *AlreadyPublishedError if it was already
+// published.
+func (c *Client) WaitForContainer(ctx context.Context, containerID ContainerID) error {
+	if !containerID.Valid() {
+		return NewValidationError(400, ErrEmptyContainerID, "Cannot wait without container ID", "container_id")
+	}
+
+	return c.waitForContainerReady(ctx, containerID, c.containerPollConfigFor(ctx))
+}
+
+// PublishContainer publishes a container created by one of the Prepare*Post
+// methods, waiting first for it to finish processing if necessary. It is
+// safe to retry: a container that is already published is never published
+// again and yields an *AlreadyPublishedError, and WithIdempotencyKey returns
+// the recorded post on repeated calls. The quota guard checks containers this
+// Client prepared as replies against the reply quota, and all others against
+// the post quota.
+func (c *Client) PublishContainer(ctx context.Context, containerID ContainerID) (*Post, error) {
+	if !containerID.Valid() {
+		return nil, NewValidationError(400, ErrEmptyContainerID, "Cannot publish without container ID", "container_id")
+	}
+
+	// Ensure we have a valid token
+	if err := c.EnsureValidToken(ctx); err != nil {
+		return nil, err
+	}
+
+	// Fail fast if the quota guard knows the post or reply quota is used up.
+	// Containers prepared by another Client are published as top-level posts.
+	prepared := c.preparedInfo(containerID.String())
+	if err := c.checkQuota(ctx, prepared.quota); err != nil {
+		return nil, err
+	}
+
+	post, err := c.publishIdempotent(ctx, func(record *IdempotencyRecord) (PostID, error) {
+		if record != nil && !record.ContainerID.Valid() {
+			record.ContainerID = containerID
+			c.saveIdempotencyRecord(record)
+		}
+
+		if err := c.waitForPublishing(ctx, containerID.String()); err != nil {
+			return "", err
+		}
+
+		if prepared.textReply {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Until(prepared.created.Add(ReplyPublishDelay))):
+			}
+		}
+
+		postID, err := c.publishContainerID(ctx, containerID.String())
+		if err != nil {
+			return "", fmt.Errorf("failed to publish container: %w", err)
+		}
+		return postID, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.forgetPrepared(containerID.String())
+	return post, nil
+}
+
+// trackPrepared records how to publish a container created from content.
+// Records of containers that have expired are dropped.
+func (c *Client) trackPrepared(containerID string, content interface{}) {
+	now := time.Now()
+
+	c.preparedMu.Lock()
+	defer c.preparedMu.Unlock()
+	if c.prepared == nil {
+		c.prepared = make(map[string]preparedContainer)
+	}
+	for id, p := range c.prepared {
+		if now.Sub(p.created) > containerLifetime {
+			delete(c.prepared, id)
+		}
+	}
+	_, isText := content.(*TextPostContent)
+	quota := publishQuota(content)
+	c.prepared[containerID] = preparedContainer{
+		quota:     quota,
+		textReply: isText && quota == EndpointReply,
+		created:   now,
+	}
+}
+
+// preparedInfo returns what was recorded for a prepared container. Containers
+// prepared by another Client are published as top-level posts.
+func (c *Client) preparedInfo(containerID string) preparedContainer {
+	c.preparedMu.Lock()
+	defer c.preparedMu.Unlock()
+	if p, ok := c.prepared[containerID]; ok {
+		return p
+	}
+	return preparedContainer{quota: EndpointPublish}
+}
+
+// forgetPrepared drops the record of a published container.
+func (c *Client) forgetPrepared(containerID string) {
+	c.preparedMu.Lock()
+	defer c.preparedMu.Unlock()
+	delete(c.prepared, containerID)
+}
@@ -0,0 +1,102 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPrepareAndPublishContainer(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	ctx := context.Background()
+
+	containerID, err := client.PrepareTextPost(ctx, &TextPostContent{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containerID != "c1" {
+		t.Errorf("expected container c1, got %s", containerID)
+	}
+	if _, publishes := srv.counts(); publishes != 0 {
+		t.Fatalf("expected prepare not to publish, got %d publishes", publishes)
+	}
+
+	if err := client.WaitForContainer(ctx, containerID); err != nil {
+		t.Fatalf("expected the container to be ready, got %v", err)
+	}
+
+	post, err := client.PublishContainer(ctx, containerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.ID != "p-c1" {
+		t.Errorf("expected post p-c1, got %s", post.ID)
+	}
+
+	if _, err := client.PublishContainer(ctx, containerID); !IsAlreadyPublishedError(err) {
+		t.Errorf("expected publishing twice to fail with AlreadyPublishedError, got %v", err)
+	}
+	if _, publishes := srv.counts(); publishes != 1 {
+		t.Errorf("expected a single publish request, got %d", publishes)
+	}
+}
+
+func TestPublishContainer_WaitsReplyDelay(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+
+	containerID, err := client.PrepareTextPost(context.Background(), &TextPostContent{Text: "hello", ReplyTo: "p-root"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.PublishContainer(ctx, containerID); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the text reply to wait ReplyPublishDelay, got %v", err)
+	}
+	if _, publishes := srv.counts(); publishes != 0 {
+		t.Fatalf("expected no publish before ReplyPublishDelay, got %d", publishes)
+	}
+
+	// Once the delay has passed since the container was prepared, it is
+	// published right away
+	client.preparedMu.Lock()
+	p := client.prepared[containerID.String()]
+	p.created = p.created.Add(-ReplyPublishDelay)
+	client.prepared[containerID.String()] = p
+	client.preparedMu.Unlock()
+
+	if _, err := client.PublishContainer(context.Background(), containerID); err != nil {
+		t.Fatal(err)
+	}
+	if _, publishes := srv.counts(); publishes != 1 {
+		t.Errorf("expected a single publish request, got %d", publishes)
+	}
+}
+
+func TestPrepareTextPost_RejectsAutoPublish(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+
+	_, err := client.PrepareTextPost(context.Background(), &TextPostContent{Text: "hello", AutoPublishText: true})
+	if !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+	if creates, _ := srv.counts(); creates != 0 {
+		t.Errorf("expected no container to be created, got %d", creates)
+	}
+}
+
+func TestPublishContainer_EmptyID(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{}`))
+
+	if _, err := client.PublishContainer(context.Background(), ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+	if err := client.WaitForContainer(context.Background(), ""); !IsValidationError(err) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
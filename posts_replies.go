@@ -3,82 +3,179 @@ package threads
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 )
 
-// CreateReply creates a reply to a specific post or reply
+// CreateReply creates a text reply to a specific post or reply. The reply
+// target is the content's ReplyTo field. Use ReplyWithDraft for image,
+// video and carousel replies, or for text replies with polls, GIFs,
+// attachments and the other features of top-level posts.
+//
+// The reply is published after ReplyPublishDelay.
 func (c *Client) CreateReply(ctx context.Context, content *PostContent) (*Post, error) {
-	if content == nil {
-		return nil, NewValidationError(400, "Content cannot be nil", "PostContent is required", "content")
+	draft, err := replyContent(content)
+	if err != nil {
+		return nil, err
 	}
+	return c.createReply(ctx, draft)
+}
 
-	if strings.TrimSpace(content.ReplyTo) == "" {
-		return nil, NewValidationError(400, "Reply target is required", "Must specify reply_to_id", "reply_to")
+// ReplyToPost creates a text reply to a specific post, regardless of the
+// content's ReplyTo field, which is left unchanged.
+func (c *Client) ReplyToPost(ctx context.Context, postID PostID, content *PostContent) (*Post, error) {
+	if !postID.Valid() {
+		return nil, NewValidationError(400, ErrEmptyPostID, "Cannot reply without specifying the post to reply to", "post_id")
 	}
 
-	// Ensure we have a valid token
-	if err := c.EnsureValidToken(ctx); err != nil {
+	draft, err := replyContent(content)
+	if err != nil {
 		return nil, err
 	}
 
-	// Build request parameters based on media type
-	mediaType := content.MediaType
-	if mediaType == "" {
-		mediaType = MediaTypeText // Default to TEXT for replies
-	}
+	// Use createReply to handle the actual reply creation
+	return c.createReply(ctx, withReplyTo(draft, postID.String()))
+}
 
-	params := url.Values{
-		"media_type":  {mediaType},
-		"reply_to_id": {content.ReplyTo},
+// ReplyWithDraft creates a reply from any post draft, so replies support the
+// same features as top-level posts. The reply target is the draft's ReplyTo
+// field.
+//
+// Supported drafts:
+//   - *TextPostContent: Creates a text reply, including polls, GIFs, link
+//     and text attachments, text entities and topic tags
+//   - *ImagePostContent: Creates an image reply
+//   - *VideoPostContent: Creates a video reply
+//   - *CarouselPostContent: Creates a carousel reply
+//
+// Text replies are published after ReplyPublishDelay; media replies are
+// published as soon as their containers finish processing.
+func (c *Client) ReplyWithDraft(ctx context.Context, draft PostDraft) (*Post, error) {
+	content, err := replyContent(draft)
+	if err != nil {
+		return nil, err
 	}
+	return c.createReply(ctx, content)
+}
 
-	// Add text if provided
-	if strings.TrimSpace(content.Text) != "" {
-		params.Set("text", content.Text)
+// createReply creates and publishes reply content returned by replyContent.
+func (c *Client) createReply(ctx context.Context, content interface{}) (*Post, error) {
+	if strings.TrimSpace(replyTarget(content)) == "" {
+		return nil, NewValidationError(400, "Reply target is required", "Must specify reply_to_id", "reply_to")
 	}
 
-	// Create container first
-	containerID, err := c.createContainer(ctx, params)
+	// Validate the content and ensure we have a valid token
+	flow, err := c.contentFlow(ctx, content)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create reply container: %w", err)
+		return nil, err
 	}
 
-	// Wait recommended 10 seconds before publishing reply
-	if c.config.Logger != nil {
-		c.config.Logger.Info("Reply container created, waiting before publishing", "container_id", containerID)
+	// Fail fast if the quota guard knows the reply quota is used up
+	if err := c.checkQuota(ctx, EndpointReply); err != nil {
+		return nil, err
 	}
 
-	// Use context timeout or fixed delay
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case <-time.After(ReplyPublishDelay):
+	if v, ok := content.(*TextPostContent); ok {
+		if v.AutoPublishText {
+			return c.publishIdempotent(ctx, func(*IdempotencyRecord) (PostID, error) {
+				return c.createAndPublishTextPostDirectly(ctx, v)
+			})
+		}
+		flow.ready = c.waitReplyPublishDelay
 	}
+	flow.container, flow.post = "reply container", "reply"
 
-	// Publish the container
-	post, err := c.publishContainer(ctx, containerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to publish reply: %w", err)
+	// Create the container, wait until it can be published and publish it
+	return c.createAndPublish(ctx, flow)
+}
+
+// replyContent checks that content is a supported, non-nil reply content
+// type and converts *PostContent to *TextPostContent.
+func replyContent(content interface{}) (interface{}, error) {
+	isNil := false
+	switch v := content.(type) {
+	case nil:
+		isNil = true
+	case *PostContent:
+		if v == nil {
+			isNil = true
+			break
+		}
+		if v.MediaType != "" && !strings.EqualFold(v.MediaType, MediaTypeText) {
+			return nil, NewValidationError(400, "Unsupported reply media type",
+				"Use ImagePostContent, VideoPostContent or CarouselPostContent for media replies", "media_type")
+		}
+		return &TextPostContent{Text: v.Text, ReplyTo: v.ReplyTo}, nil
+	case *TextPostContent:
+		isNil = v == nil
+	case *ImagePostContent:
+		isNil = v == nil
+	case *VideoPostContent:
+		isNil = v == nil
+	case *CarouselPostContent:
+		isNil = v == nil
+	default:
+		return nil, NewValidationError(400, "Unsupported content type",
+			fmt.Sprintf("unsupported content type for reply: %T", content), "content")
 	}
 
-	return post, nil
+	if isNil {
+		return nil, NewValidationError(400, "Content cannot be nil", "Reply content is required", "content")
+	}
+	return content, nil
 }
 
-// ReplyToPost creates a reply to a specific post
-func (c *Client) ReplyToPost(ctx context.Context, postID PostID, content *PostContent) (*Post, error) {
-	if !postID.Valid() {
-		return nil, NewValidationError(400, ErrEmptyPostID, "Cannot reply without specifying the post to reply to", "post_id")
+// replyTarget returns the ReplyTo field of reply content.
+func replyTarget(content interface{}) string {
+	switch v := content.(type) {
+	case *TextPostContent:
+		return v.ReplyTo
+	case *ImagePostContent:
+		return v.ReplyTo
+	case *VideoPostContent:
+		return v.ReplyTo
+	case *CarouselPostContent:
+		return v.ReplyTo
+	default:
+		return ""
 	}
+}
 
-	if content == nil {
-		return nil, NewValidationError(400, "Content cannot be nil", "PostContent is required", "content")
+// withReplyTo returns a copy of reply content that replies to replyTo.
+func withReplyTo(content interface{}, replyTo string) interface{} {
+	switch v := content.(type) {
+	case *TextPostContent:
+		reply := *v
+		reply.ReplyTo = replyTo
+		return &reply
+	case *ImagePostContent:
+		reply := *v
+		reply.ReplyTo = replyTo
+		return &reply
+	case *VideoPostContent:
+		reply := *v
+		reply.ReplyTo = replyTo
+		return &reply
+	case *CarouselPostContent:
+		reply := *v
+		reply.ReplyTo = replyTo
+		return &reply
+	default:
+		return content
 	}
+}
 
-	// Set the reply-to field
-	content.ReplyTo = postID.String()
+// waitReplyPublishDelay waits the recommended delay before publishing a
+// text reply container.
+func (c *Client) waitReplyPublishDelay(ctx context.Context, containerID string) error {
+	if c.config.Logger != nil {
+		c.config.Logger.Info("Reply container created, waiting before publishing", "container_id", containerID)
+	}
 
-	// Use CreateReply to handle the actual reply creation
-	return c.CreateReply(ctx, content)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(ReplyPublishDelay):
+		return nil
+	}
 }
@@ -3,8 +3,10 @@ package threads
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateReply_Success(t *testing.T) {
@@ -274,3 +276,71 @@ func TestCreateReply_PublishError(t *testing.T) {
 		t.Errorf("expected publish error, got: %v", err)
 	}
 }
+
+func TestCreateReply_ImageContent(t *testing.T) {
+	var form url.Values
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/12345/threads_publish"):
+			_, _ = w.Write([]byte(`{"id":"reply_post"}`))
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/12345/threads"):
+			_ = r.ParseForm()
+			form = r.PostForm
+			_, _ = w.Write([]byte(`{"id":"reply_container"}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/reply_container"):
+			_, _ = w.Write([]byte(`{"id":"reply_container","status":"FINISHED"}`))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/reply_post"):
+			_, _ = w.Write([]byte(`{"id":"reply_post","media_type":"IMAGE"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}
+
+	client := testClient(t, http.HandlerFunc(handler))
+
+	start := time.Now()
+	post, err := client.ReplyWithDraft(context.Background(), &ImagePostContent{
+		ReplyTo:        "target_post",
+		ImageURL:       "https://example.com/img.jpg",
+		AltText:        "A cat",
+		TopicTag:       "cats",
+		IsSpoilerMedia: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if post.ID != "reply_post" {
+		t.Errorf("expected reply_post, got %s", post.ID)
+	}
+	if time.Since(start) >= ReplyPublishDelay {
+		t.Error("expected media replies to publish once processed, without the text reply delay")
+	}
+
+	for key, want := range map[string]string{
+		"media_type":       MediaTypeImage,
+		"image_url":        "https://example.com/img.jpg",
+		"alt_text":         "A cat",
+		"topic_tag":        "cats",
+		"is_spoiler_media": "true",
+		"reply_to_id":      "target_post",
+	} {
+		if got := form.Get(key); got != want {
+			t.Errorf("expected %s=%s, got %q", key, want, got)
+		}
+	}
+}
+
+func TestCreateReply_UnsupportedContent(t *testing.T) {
+	client := testClient(t, jsonHandler(200, `{}`))
+	ctx := context.Background()
+
+	if _, err := client.CreateReply(ctx, &PostContent{Text: "pic", MediaType: MediaTypeImage, ReplyTo: "parent_post_123"}); !IsValidationError(err) {
+		t.Errorf("media PostContent: expected ValidationError, got %v", err)
+	}
+	for _, draft := range []PostDraft{nil, (*VideoPostContent)(nil)} {
+		if _, err := client.ReplyWithDraft(ctx, draft); !IsValidationError(err) {
+			t.Errorf("%T: expected ValidationError, got %v", draft, err)
+		}
+	}
+}
@@ -118,8 +118,8 @@ func (c *Client) ValidateImagePostContent(content *ImagePostContent) error {
 		return err
 	}
 
-	// Validate image URL
-	if err := validator.ValidateMediaURL(content.ImageURL, "image"); err != nil {
+	// Validate image URL, or local image and media host
+	if err := c.validateMediaSource(content.ImageURL, content.ImagePath, content.ImageReader, "image"); err != nil {
 		return err
 	}
 
@@ -168,8 +168,8 @@ func (c *Client) ValidateVideoPostContent(content *VideoPostContent) error {
 		return err
 	}
 
-	// Validate video URL
-	if err := validator.ValidateMediaURL(content.VideoURL, "video"); err != nil {
+	// Validate video URL, or local video and media host
+	if err := c.validateMediaSource(content.VideoURL, content.VideoPath, content.VideoReader, "video"); err != nil {
 		return err
 	}
 
@@ -219,10 +219,25 @@ func (c *Client) ValidateCarouselPostContent(content *CarouselPostContent) error
 	}
 
 	// Validate children count (2-20 limit)
-	if err := validator.ValidateCarouselChildren(len(content.Children)); err != nil {
+	if err := validator.ValidateCarouselChildren(len(content.Children) + len(content.Items)); err != nil {
 		return err
 	}
 
+	// Validate item media types and sources
+	for i, item := range content.Items {
+		mediaType := strings.ToUpper(item.MediaType)
+		if mediaType != MediaTypeImage && mediaType != MediaTypeVideo {
+			return NewValidationError(400, "Invalid media type",
+				fmt.Sprintf("Carousel item %d media type must be IMAGE or VIDEO", i+1), "items")
+		}
+		if err := c.validateMediaSource(item.URL, item.Path, item.Reader, strings.ToLower(mediaType)); err != nil {
+			return err
+		}
+		if err := validator.ValidateAltText(item.AltText); err != nil {
+			return err
+		}
+	}
+
 	// Validate topic tag if present
 	if content.TopicTag != "" {
 		if err := validator.ValidateTopicTag(content.TopicTag); err != nil {
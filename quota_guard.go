@@ -0,0 +1,220 @@
+package threads
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QuotaGuardOptions configures the quota guard enabled by
+// Client.EnableQuotaGuard. Zero values use defaults.
+type QuotaGuardOptions struct {
+	// TTL is how long fetched publishing limits are trusted before
+	// GetPublishingLimits is called again. Default: DefaultQuotaGuardTTL.
+	TTL time.Duration
+
+	// RetryInterval is how long to wait before fetching the limits again
+	// after a failed fetch. Default: DefaultQuotaGuardRetryInterval.
+	RetryInterval time.Duration
+}
+
+// quotaGuard caches PublishingLimits and counts calls made since they were
+// fetched, so quota exhaustion can be detected without a round trip.
+type quotaGuard struct {
+	ttl           time.Duration
+	retryInterval time.Duration
+
+	mu        sync.Mutex
+	limits    *PublishingLimits
+	fetchedAt time.Time
+	local     map[EndpointClass]int // Calls made since fetchedAt
+	retryAt   time.Time             // No fetch before this time after a failure
+	fetch     *quotaFetch           // In-flight fetch, if any
+}
+
+// quotaFetch is an in-flight GetPublishingLimits shared by concurrent checks.
+type quotaFetch struct {
+	done   chan struct{}
+	during map[EndpointClass]int // Calls made while the fetch was in flight
+}
+
+// EnableQuotaGuard makes CreateTextPost and the other Create*Post methods,
+// the reply methods, DeletePost, KeywordSearch and SearchLocations check the
+// account's 24-hour publishing quotas before doing any work. When a quota
+// is used up they fail fast with a *QuotaExceededError instead of letting
+// the API reject the call after containers were already created.
+//
+// Limits come from GetPublishingLimits, cached for opts.TTL; calls made in
+// between are counted locally. If the limits cannot be fetched, calls are
+// allowed through and the fetch is retried after opts.RetryInterval.
+// Enabling the guard again replaces it and discards the cache.
+func (c *Client) EnableQuotaGuard(opts *QuotaGuardOptions) {
+	g := newQuotaGuard(opts)
+
+	c.mu.Lock()
+	c.quotaGuard = g
+	c.mu.Unlock()
+}
+
+// newQuotaGuard creates a quota guard with an empty cache.
+func newQuotaGuard(opts *QuotaGuardOptions) *quotaGuard {
+	g := &quotaGuard{
+		ttl:           DefaultQuotaGuardTTL,
+		retryInterval: DefaultQuotaGuardRetryInterval,
+		local:         make(map[EndpointClass]int),
+	}
+	if opts != nil && opts.TTL > 0 {
+		g.ttl = opts.TTL
+	}
+	if opts != nil && opts.RetryInterval > 0 {
+		g.retryInterval = opts.RetryInterval
+	}
+	return g
+}
+
+// DisableQuotaGuard turns off quota checks enabled by EnableQuotaGuard.
+func (c *Client) DisableQuotaGuard() {
+	c.mu.Lock()
+	c.quotaGuard = nil
+	c.mu.Unlock()
+}
+
+func (c *Client) getQuotaGuard() *quotaGuard {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.quotaGuard
+}
+
+// checkQuota returns a *QuotaExceededError if a call in class would exceed
+// its quota. It returns nil when the guard is disabled.
+func (c *Client) checkQuota(ctx context.Context, class EndpointClass) error {
+	g := c.getQuotaGuard()
+	if g == nil {
+		return nil
+	}
+	return c.checkQuotaWith(ctx, g, class)
+}
+
+// checkQuotaWith checks a call in class against the limits cached by g.
+func (c *Client) checkQuotaWith(ctx context.Context, g *quotaGuard, class EndpointClass) error {
+	if err := c.refreshQuotaLimits(ctx, g); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.limits == nil {
+		return nil
+	}
+
+	quota, used := quotaFor(g.limits, class)
+	if quota.QuotaTotal <= 0 {
+		return nil
+	}
+	used += g.local[class]
+	if used < quota.QuotaTotal {
+		return nil
+	}
+
+	// The quotas are rolling windows and the API does not say when earlier
+	// calls expire, so capacity can only be confirmed at the next refresh.
+	return NewQuotaExceededError(class, quota.QuotaTotal, used, g.fetchedAt.Add(g.ttl))
+}
+
+// refreshQuotaLimits fetches the publishing limits if the cached ones have
+// expired. Concurrent callers share one fetch, which runs without holding
+// g.mu; after a failed fetch no other is made for g.retryInterval.
+func (c *Client) refreshQuotaLimits(ctx context.Context, g *quotaGuard) error {
+	g.mu.Lock()
+	now := time.Now()
+	if (g.limits != nil && now.Before(g.fetchedAt.Add(g.ttl))) || now.Before(g.retryAt) {
+		g.mu.Unlock()
+		return nil
+	}
+	fetch := g.fetch
+	if fetch != nil {
+		g.mu.Unlock()
+		select {
+		case <-fetch.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	fetch = &quotaFetch{done: make(chan struct{}), during: make(map[EndpointClass]int)}
+	g.fetch = fetch
+	g.mu.Unlock()
+
+	limits, err := c.GetPublishingLimits(ctx)
+
+	g.mu.Lock()
+	g.fetch = nil
+	switch {
+	case err == nil:
+		g.limits = limits
+		g.fetchedAt = now
+		g.local = fetch.during
+	case ctx.Err() == nil:
+		g.retryAt = time.Now().Add(g.retryInterval)
+	}
+	g.mu.Unlock()
+	close(fetch.done)
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if c.config.Logger != nil {
+			c.config.Logger.Warn("Quota guard could not refresh publishing limits",
+				"error", err.Error(),
+				"retry_in", g.retryInterval)
+		}
+	}
+	return nil
+}
+
+// recordQuotaUse counts a successful call in class against the cached limits.
+// Calls made while limits are being fetched also count against the new
+// limits, which may not include them yet.
+func (c *Client) recordQuotaUse(class EndpointClass) {
+	if g := c.getQuotaGuard(); g != nil {
+		g.record(class)
+	}
+}
+
+// record counts a call in class against the cached limits.
+func (g *quotaGuard) record(class EndpointClass) {
+	g.mu.Lock()
+	g.local[class]++
+	if g.fetch != nil {
+		g.fetch.during[class]++
+	}
+	g.mu.Unlock()
+}
+
+// publishQuota returns the quota class content counts against when created:
+// EndpointReply for replies, otherwise EndpointPublish.
+func publishQuota(content interface{}) EndpointClass {
+	if replyTarget(content) != "" {
+		return EndpointReply
+	}
+	return EndpointPublish
+}
+
+// quotaFor returns the quota configuration and server-reported usage for class.
+func quotaFor(limits *PublishingLimits, class EndpointClass) (QuotaConfig, int) {
+	switch class {
+	case EndpointPublish:
+		return limits.Config, limits.QuotaUsage
+	case EndpointReply:
+		return limits.ReplyConfig, limits.ReplyQuotaUsage
+	case EndpointDelete:
+		return limits.DeleteConfig, limits.DeleteQuotaUsage
+	case EndpointSearch:
+		return limits.SearchConfig, limits.SearchQuotaUsage
+	case EndpointLocationSearch:
+		return limits.LocationSearchConfig, limits.LocationSearchQuotaUsage
+	default:
+		return QuotaConfig{}, 0
+	}
+}
@@ -0,0 +1,195 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// quotaServer serves publishing limits with a configurable search and post
+// usage and counts the other requests it receives.
+type quotaServer struct {
+	mu          sync.Mutex
+	searchUsed  int
+	postsUsed   int
+	limitsCalls int
+	otherCalls  []string
+	failLimits  bool
+}
+
+func (s *quotaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.HasSuffix(r.URL.Path, "/threads_publishing_limit") {
+		s.limitsCalls++
+		if s.failLimits {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Unsupported request","type":"OAuthException","code":100}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{` +
+			`"quota_usage":` + strconv.Itoa(s.postsUsed) + `,"config":{"quota_total":250,"quota_duration":86400},` +
+			`"search_quota_usage":` + strconv.Itoa(s.searchUsed) + `,"search_config":{"quota_total":2200,"quota_duration":86400}}]}`))
+		return
+	}
+
+	s.otherCalls = append(s.otherCalls, r.Method+" "+r.URL.Path)
+	_, _ = w.Write([]byte(`{"data":[]}`))
+}
+
+func (s *quotaServer) calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.otherCalls...)
+}
+
+func TestQuotaGuard_FailsFastWhenExhausted(t *testing.T) {
+	srv := &quotaServer{searchUsed: 2199, postsUsed: 250}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(nil)
+	ctx := context.Background()
+
+	if _, err := client.KeywordSearch(ctx, "golang", nil); err != nil {
+		t.Fatalf("expected the last search in quota to succeed, got %v", err)
+	}
+
+	_, err := client.KeywordSearch(ctx, "golang", nil)
+	if !IsQuotaExceededError(err) {
+		t.Fatalf("expected QuotaExceededError, got %v", err)
+	}
+	quotaErr := err.(*QuotaExceededError)
+	if quotaErr.Quota != EndpointSearch || quotaErr.Limit != 2200 || quotaErr.Used != 2200 {
+		t.Errorf("unexpected quota error %+v", quotaErr)
+	}
+	if quotaErr.ResetAt.Before(time.Now()) {
+		t.Errorf("expected ResetAt in the future, got %s", quotaErr.ResetAt)
+	}
+
+	_, err = client.CreateTextPost(ctx, &TextPostContent{Text: "hello"})
+	if !IsQuotaExceededError(err) {
+		t.Fatalf("expected post quota to be exhausted, got %v", err)
+	}
+
+	if calls := srv.calls(); len(calls) != 1 {
+		t.Errorf("expected only the first search to reach the API, got %v", calls)
+	}
+	if srv.limitsCalls != 1 {
+		t.Errorf("expected publishing limits to be cached, fetched %d times", srv.limitsCalls)
+	}
+}
+
+func TestQuotaGuard_RefreshesAfterTTL(t *testing.T) {
+	srv := &quotaServer{searchUsed: 2200}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(&QuotaGuardOptions{TTL: 20 * time.Millisecond})
+	ctx := context.Background()
+
+	if _, err := client.KeywordSearch(ctx, "golang", nil); !IsQuotaExceededError(err) {
+		t.Fatalf("expected QuotaExceededError, got %v", err)
+	}
+
+	srv.mu.Lock()
+	srv.searchUsed = 100
+	srv.mu.Unlock()
+	time.Sleep(30 * time.Millisecond)
+
+	if _, err := client.KeywordSearch(ctx, "golang", nil); err != nil {
+		t.Errorf("expected search to be allowed after the quota freed up, got %v", err)
+	}
+
+	client.DisableQuotaGuard()
+	srv.mu.Lock()
+	srv.searchUsed = 2200
+	srv.mu.Unlock()
+	if _, err := client.KeywordSearch(ctx, "golang", nil); err != nil {
+		t.Errorf("expected no checks once the guard is disabled, got %v", err)
+	}
+}
+
+func TestQuotaGuard_AllowsCallsWhenLimitsUnavailable(t *testing.T) {
+	srv := &quotaServer{failLimits: true}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.KeywordSearch(context.Background(), "golang", nil); err != nil {
+			t.Errorf("expected the guard to fail open, got %v", err)
+		}
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.limitsCalls != 1 {
+		t.Errorf("expected no refetch before the retry interval, got %d fetches", srv.limitsCalls)
+	}
+}
+
+func TestQuotaGuard_SharesConcurrentFetches(t *testing.T) {
+	srv := &quotaServer{}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.checkQuota(context.Background(), EndpointSearch); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.limitsCalls != 1 {
+		t.Errorf("expected one shared fetch, got %d", srv.limitsCalls)
+	}
+}
+
+func TestQuotaGuard_RepliesCheckReplyQuota(t *testing.T) {
+	srv := &quotaServer{postsUsed: 250}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(nil)
+
+	_, err := client.CreateTextPost(context.Background(), &TextPostContent{Text: "hello"})
+	if !IsQuotaExceededError(err) {
+		t.Fatalf("expected QuotaExceededError for a post, got %v", err)
+	}
+
+	// The reply quota is not used up, so the reply goes on to the API
+	_, err = client.CreateTextPost(context.Background(), &TextPostContent{Text: "hello", ReplyTo: "987"})
+	if IsQuotaExceededError(err) {
+		t.Errorf("expected a reply not to be checked against the post quota, got %v", err)
+	}
+	if len(srv.calls()) == 0 {
+		t.Error("expected the reply to be sent")
+	}
+}
+
+func TestQuotaGuard_PreparedRepliesCheckReplyQuota(t *testing.T) {
+	srv := &quotaServer{postsUsed: 250}
+	client := testClient(t, srv)
+	client.EnableQuotaGuard(nil)
+	client.trackPrepared("c1", &TextPostContent{Text: "hello", ReplyTo: "987"})
+
+	if _, err := client.PublishContainer(context.Background(), "c2"); !IsQuotaExceededError(err) {
+		t.Fatalf("expected QuotaExceededError for a prepared post, got %v", err)
+	}
+
+	// The reply quota is not used up, so the prepared reply goes on to the API
+	_, err := client.PublishContainer(context.Background(), "c1")
+	if IsQuotaExceededError(err) {
+		t.Errorf("expected a prepared reply not to be checked against the post quota, got %v", err)
+	}
+	if len(srv.calls()) == 0 {
+		t.Error("expected the prepared reply to be sent")
+	}
+}
@@ -20,8 +20,19 @@ type RateLimiter struct {
 	logger            Logger        // Logger for rate limit events
 	rateLimited       bool          // True if we've been rate limited by the API
 	lastRateLimitTime time.Time     // When we were last rate limited
+	usage             *APIUsage     // Most recent usage reported by the API
+
+	buckets map[EndpointClass]*tokenBucket // Proactive per-endpoint pacing; nil when disabled
+
+	store     RateLimitStore // Shared rate limit state
+	ownsStore bool           // True when store is the default private memory store
+	appStore  RateLimitStore // Shared app-level throttling state; nil if not shared
 }
 
+// usageThrottleWait is how long to back off when usage headers report 100%
+// without an estimated time to regain access.
+const usageThrottleWait = time.Minute
+
 // RateLimiterConfig holds configuration for the rate limiter
 type RateLimiterConfig struct {
 	InitialLimit      int           // Initial rate limit (will be updated from API responses)
@@ -29,6 +40,15 @@ type RateLimiterConfig struct {
 	MaxBackoff        time.Duration // Maximum backoff duration
 	QueueSize         int           // Size of request queue
 	Logger            Logger        // Logger instance
+
+	// Store shares rate limit state with other limiters, for example other
+	// replicas using the same account. Default: a private MemoryRateLimitStore.
+	Store RateLimitStore
+
+	// AppStore shares throttling reported by X-App-Usage, which applies to
+	// every account of the app, with the limiters of other accounts.
+	// Default: none.
+	AppStore RateLimitStore
 }
 
 // NewRateLimiter creates a new rate limiter with the given configuration
@@ -46,6 +66,11 @@ func NewRateLimiter(config *RateLimiterConfig) *RateLimiter {
 		config.QueueSize = 100
 	}
 
+	store, ownsStore := config.Store, false
+	if store == nil {
+		store, ownsStore = NewMemoryRateLimitStore(), true
+	}
+
 	return &RateLimiter{
 		limit:             config.InitialLimit,
 		remaining:         config.InitialLimit,
@@ -54,12 +79,18 @@ func NewRateLimiter(config *RateLimiterConfig) *RateLimiter {
 		backoffMultiplier: config.BackoffMultiplier,
 		maxBackoff:        config.MaxBackoff,
 		logger:            config.Logger,
+		store:             store,
+		ownsStore:         ownsStore,
+		appStore:          config.AppStore,
 	}
 }
 
 // ShouldWait returns true if we should wait before making a request
-// Only returns true if we've been explicitly rate limited by the API
+// Only returns true if we've been explicitly rate limited by the API, either
+// through this limiter or through another one sharing its RateLimitStore
 func (rl *RateLimiter) ShouldWait() bool {
+	rl.syncFromStore()
+
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
@@ -115,6 +146,9 @@ func (rl *RateLimiter) Wait(ctx context.Context) error {
 		case <-time.After(waitTime):
 		}
 
+		// Pick up extensions recorded by limiters sharing the store
+		rl.syncFromStore()
+
 		rl.mu.Lock()
 		if rl.resetTime.After(originalResetTime) {
 			// Rate limit was extended while we slept; recalculate and loop
@@ -137,29 +171,89 @@ func (rl *RateLimiter) UpdateFromHeaders(rateLimitInfo *RateLimitInfo) {
 	}
 
 	rl.mu.Lock()
-	defer rl.mu.Unlock()
 
 	// Update rate limit information from headers
-	if rateLimitInfo.Limit > 0 {
-		rl.limit = rateLimitInfo.Limit
-	}
+	if rateLimitInfo.hasWindow() {
+		if rateLimitInfo.Limit > 0 {
+			rl.limit = rateLimitInfo.Limit
+		}
 
-	if rateLimitInfo.Remaining >= 0 {
-		rl.remaining = rateLimitInfo.Remaining
+		if rateLimitInfo.Remaining >= 0 {
+			rl.remaining = rateLimitInfo.Remaining
+		}
+
+		if !rateLimitInfo.Reset.IsZero() {
+			rl.resetTime = rateLimitInfo.Reset
+		}
 	}
 
-	if !rateLimitInfo.Reset.IsZero() {
-		rl.resetTime = rateLimitInfo.Reset
+	var until time.Time
+	if rateLimitInfo.Usage != nil {
+		until = rl.updateUsageLocked(rateLimitInfo.Usage)
 	}
 
 	rl.logRateLimitUpdate(rateLimitInfo)
+	rl.mu.Unlock()
+
+	if rateLimitInfo.hasWindow() {
+		if err := rl.store.UpdateFromHeaders(rateLimitInfo); err != nil {
+			rl.logStoreError(err)
+		}
+	}
+	rl.saveRateLimited(until)
+	rl.saveAppThrottled(rateLimitInfo.Usage, until)
 }
 
-// MarkRateLimited marks that we've been rate limited by the API
-func (rl *RateLimiter) MarkRateLimited(resetTime time.Time) {
+// UpdateUsage records Graph API usage from X-App-Usage and
+// X-Business-Use-Case-Usage. When usage shows the app is throttled, the
+// limiter is marked rate limited until access is expected to return.
+func (rl *RateLimiter) UpdateUsage(usage *APIUsage) {
+	if usage == nil {
+		return
+	}
+
 	rl.mu.Lock()
-	defer rl.mu.Unlock()
+	until := rl.updateUsageLocked(usage)
+	rl.mu.Unlock()
+
+	rl.saveRateLimited(until)
+	rl.saveAppThrottled(usage, until)
+}
+
+// updateUsageLocked records usage and returns the time the limiter is now
+// rate limited until, or the zero time if usage does not require waiting.
+func (rl *RateLimiter) updateUsageLocked(usage *APIUsage) time.Time {
+	rl.usage = usage
+
+	wait := usage.RegainAccessIn()
+	if wait == 0 && usage.MaxPercent() >= 100 {
+		wait = usageThrottleWait
+	}
+	if wait == 0 {
+		return time.Time{}
+	}
+
+	until := time.Now().Add(wait)
+	if !rl.rateLimited || until.After(rl.resetTime) {
+		rl.resetTime = until
+	}
+	rl.rateLimited = true
+	rl.lastRateLimitTime = time.Now()
+
+	if rl.logger != nil {
+		rl.logger.Info("Throttled according to API usage headers",
+			"usage_percent", usage.MaxPercent(),
+			"reset_time", rl.resetTime.Format(time.RFC3339),
+		)
+	}
+	return rl.resetTime
+}
 
+// MarkRateLimited marks that we've been rate limited by the API. The reset
+// time is also recorded in the limiter's RateLimitStore so that limiters
+// sharing it wait as well.
+func (rl *RateLimiter) MarkRateLimited(resetTime time.Time) {
+	rl.mu.Lock()
 	rl.rateLimited = true
 	rl.lastRateLimitTime = time.Now()
 
@@ -172,6 +266,60 @@ func (rl *RateLimiter) MarkRateLimited(resetTime time.Time) {
 			"reset_time", rl.resetTime.Format(time.RFC3339),
 		)
 	}
+	until := rl.resetTime
+	rl.mu.Unlock()
+
+	rl.saveRateLimited(until)
+}
+
+// saveRateLimited records until in the store unless it is zero.
+func (rl *RateLimiter) saveRateLimited(until time.Time) {
+	if until.IsZero() {
+		return
+	}
+	if err := rl.store.MarkRateLimited(until); err != nil {
+		rl.logStoreError(err)
+	}
+}
+
+// saveAppThrottled records until in the app store when usage shows the
+// whole app is throttled.
+func (rl *RateLimiter) saveAppThrottled(usage *APIUsage, until time.Time) {
+	if rl.appStore == nil || until.IsZero() || !usage.appThrottled() {
+		return
+	}
+	if err := rl.appStore.MarkRateLimited(until); err != nil {
+		rl.logStoreError(err)
+	}
+}
+
+// syncFromStore adopts waits recorded in the stores by other limiters when
+// they end later than the local one. A private default store holds nothing
+// the limiter does not already know, so it is not asked.
+func (rl *RateLimiter) syncFromStore() {
+	var wait time.Duration
+	if !rl.ownsStore {
+		_, wait = rl.store.ShouldWait()
+	}
+	if rl.appStore != nil {
+		if _, appWait := rl.appStore.ShouldWait(); appWait > wait {
+			wait = appWait
+		}
+	}
+	if wait <= 0 {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	until := now.Add(wait)
+	if !rl.rateLimited || until.After(rl.resetTime) {
+		rl.rateLimited = true
+		rl.resetTime = until
+		rl.lastRateLimitTime = now
+	}
 }
 
 // GetStatus returns current rate limit status
@@ -184,6 +332,7 @@ func (rl *RateLimiter) GetStatus() RateLimitStatus {
 		Remaining: rl.remaining,
 		ResetTime: rl.resetTime,
 		ResetIn:   time.Until(rl.resetTime),
+		Usage:     rl.usage,
 	}
 }
 
@@ -193,14 +342,20 @@ type RateLimitStatus struct {
 	Remaining int           `json:"remaining"`
 	ResetTime time.Time     `json:"reset_time"`
 	ResetIn   time.Duration `json:"reset_in"`
+	Usage     *APIUsage     `json:"usage,omitempty"` // Most recent usage headers, if any
 }
 
-// IsNearLimit returns true if we're close to hitting the rate limit
-// This is now informational only and doesn't block requests
+// IsNearLimit returns true if we're close to hitting the rate limit, either
+// by the X-RateLimit-* window or by the usage percentages in X-App-Usage /
+// X-Business-Use-Case-Usage. This is informational only and doesn't block requests
 func (rl *RateLimiter) IsNearLimit(threshold float64) bool {
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
+	if rl.usage != nil && float64(rl.usage.MaxPercent())/100 >= threshold {
+		return true
+	}
+
 	if rl.limit == 0 {
 		return false
 	}
@@ -211,6 +366,8 @@ func (rl *RateLimiter) IsNearLimit(threshold float64) bool {
 
 // IsRateLimited returns true if we're currently rate limited by the API
 func (rl *RateLimiter) IsRateLimited() bool {
+	rl.syncFromStore()
+
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
@@ -275,15 +432,21 @@ func (rl *RateLimiter) GetQueueLength() int {
 	return len(rl.requestQueue)
 }
 
-// Reset resets the rate limiter state (useful for testing)
+// Reset resets the rate limiter state (useful for testing). A RateLimitStore
+// passed in RateLimiterConfig is left untouched.
 func (rl *RateLimiter) Reset() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	if store, ok := rl.store.(*MemoryRateLimitStore); ok && rl.ownsStore {
+		store.reset()
+	}
+
 	rl.remaining = rl.limit
 	rl.resetTime = time.Now().Add(time.Hour)
 	rl.lastRequestTime = time.Time{}
 	rl.rateLimited = false
+	rl.usage = nil
 
 	// Drain the queue
 	for len(rl.requestQueue) > 0 {
@@ -330,6 +493,16 @@ func (rl *RateLimiter) logRateLimitReset() {
 	)
 }
 
+func (rl *RateLimiter) logStoreError(err error) {
+	if rl.logger == nil {
+		return
+	}
+
+	rl.logger.Warn("Rate limit store unavailable, using local state",
+		"error", err.Error(),
+	)
+}
+
 func (rl *RateLimiter) logQueueProcessError(err error) {
 	if rl.logger == nil {
 		return
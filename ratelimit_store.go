@@ -0,0 +1,266 @@
+package threads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RateLimitState is the rate limit state kept in a RateLimitStore.
+type RateLimitState struct {
+	// RateLimitedUntil is when the API is expected to accept requests again.
+	// It is zero or in the past when no one has been rate limited.
+	RateLimitedUntil time.Time `json:"rate_limited_until"`
+
+	// Limit, Remaining and ResetTime are the last X-RateLimit-* window
+	// reported to any client sharing the store.
+	Limit     int       `json:"limit,omitempty"`
+	Remaining int       `json:"remaining,omitempty"`
+	ResetTime time.Time `json:"reset_time"`
+
+	// UpdatedAt is when the window was last written.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RateLimitStore holds rate limit state so it can be shared by every
+// RateLimiter calling the API with the same app and account, for example
+// replicas of a service. Implementations must be safe for concurrent use;
+// a RateLimiter falls back to its local state when a store call fails.
+// Implementations that keep a RateLimitState can answer ShouldWait with
+// RateLimitState.ShouldWait.
+type RateLimitStore interface {
+	// MarkRateLimited records that the API rejected a request and should not
+	// be called again before until. An earlier until than the one already
+	// stored must not shorten the wait.
+	MarkRateLimited(until time.Time) error
+
+	// UpdateFromHeaders records the rate limit window reported by a response.
+	UpdateFromHeaders(info *RateLimitInfo) error
+
+	// ShouldWait reports whether requests should wait according to the
+	// recorded state, and for how long. It is called before every request,
+	// so it should be cheap, for example by caching until the underlying
+	// state changes.
+	ShouldWait() (bool, time.Duration)
+}
+
+// ShouldWait reports whether requests should wait at now according to the
+// state, and for how long: until RateLimitedUntil, or until ResetTime when
+// the recorded window has no requests remaining.
+func (s *RateLimitState) ShouldWait(now time.Time) (bool, time.Duration) {
+	until := s.RateLimitedUntil
+	if s.Limit > 0 && s.Remaining <= 0 && s.ResetTime.After(until) {
+		until = s.ResetTime
+	}
+	if !until.After(now) {
+		return false, 0
+	}
+	return true, until.Sub(now)
+}
+
+// mergeRateLimited extends state.RateLimitedUntil to until if it is later.
+func (s *RateLimitState) mergeRateLimited(until time.Time) {
+	if until.After(s.RateLimitedUntil) {
+		s.RateLimitedUntil = until
+	}
+}
+
+// mergeHeaders copies the window from info into the state.
+func (s *RateLimitState) mergeHeaders(info *RateLimitInfo) {
+	if info == nil || !info.hasWindow() {
+		return
+	}
+	if info.Limit > 0 {
+		s.Limit = info.Limit
+	}
+	if info.Remaining >= 0 {
+		s.Remaining = info.Remaining
+	}
+	if !info.Reset.IsZero() {
+		s.ResetTime = info.Reset
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// MemoryRateLimitStore keeps rate limit state in memory. It is the default
+// store of a RateLimiter and can be shared by clients in the same process.
+type MemoryRateLimitStore struct {
+	mu    sync.Mutex
+	state RateLimitState
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory rate limit store.
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{}
+}
+
+// MarkRateLimited records that requests should wait until the given time.
+func (s *MemoryRateLimitStore) MarkRateLimited(until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.mergeRateLimited(until)
+	return nil
+}
+
+// UpdateFromHeaders records the rate limit window from a response.
+func (s *MemoryRateLimitStore) UpdateFromHeaders(info *RateLimitInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.mergeHeaders(info)
+	return nil
+}
+
+// ShouldWait reports whether requests should wait, and for how long.
+func (s *MemoryRateLimitStore) ShouldWait() (bool, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.ShouldWait(time.Now())
+}
+
+// Load returns the current state.
+func (s *MemoryRateLimitStore) Load() (RateLimitState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *MemoryRateLimitStore) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = RateLimitState{}
+}
+
+// FileRateLimitStore keeps rate limit state in a JSON file so that processes
+// on the same host, or on hosts sharing a filesystem with working advisory
+// locks, back off together. Updates hold an exclusive lock on a sidecar
+// "<path>.lock" file and replace the state file atomically. ShouldWait and
+// Load only read the file again when it has been replaced since the last
+// read.
+type FileRateLimitStore struct {
+	path string
+	mu   sync.Mutex
+
+	cached     RateLimitState // State read from the file described by cachedInfo
+	cachedInfo os.FileInfo
+}
+
+// NewFileRateLimitStore creates a store backed by the file at path. The file
+// is created on the first update.
+func NewFileRateLimitStore(path string) (*FileRateLimitStore, error) {
+	if path == "" {
+		return nil, NewValidationError(400, "Rate limit file path is required", "FileRateLimitStore needs a non-empty path", "path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create rate limit directory: %w", err)
+	}
+	return &FileRateLimitStore{path: path}, nil
+}
+
+// MarkRateLimited records that requests should wait until the given time.
+func (s *FileRateLimitStore) MarkRateLimited(until time.Time) error {
+	return s.update(func(state *RateLimitState) { state.mergeRateLimited(until) })
+}
+
+// UpdateFromHeaders records the rate limit window from a response.
+func (s *FileRateLimitStore) UpdateFromHeaders(info *RateLimitInfo) error {
+	if info == nil || !info.hasWindow() {
+		return nil
+	}
+	return s.update(func(state *RateLimitState) { state.mergeHeaders(info) })
+}
+
+// ShouldWait reports whether requests should wait, and for how long. An
+// unreadable file does not make requests wait.
+func (s *FileRateLimitStore) ShouldWait() (bool, time.Duration) {
+	state, err := s.Load()
+	if err != nil {
+		return false, 0
+	}
+	return state.ShouldWait(time.Now())
+}
+
+// Load returns the current state, reading it from disk if the file changed.
+func (s *FileRateLimitStore) Load() (RateLimitState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Updates replace the file, so an unchanged file means unchanged state.
+	// Stat before reading: if the file is replaced in between, the next
+	// Load sees a mismatch and reads it again.
+	info, err := os.Stat(s.path)
+	if err == nil && s.cachedInfo != nil && os.SameFile(info, s.cachedInfo) &&
+		info.ModTime().Equal(s.cachedInfo.ModTime()) && info.Size() == s.cachedInfo.Size() {
+		return s.cached, nil
+	}
+
+	unlock, err := s.lock(false)
+	if err != nil {
+		return RateLimitState{}, err
+	}
+	defer unlock()
+
+	state, err := s.read()
+	if err != nil {
+		return state, err
+	}
+	s.cached, s.cachedInfo = state, info
+	return state, nil
+}
+
+func (s *FileRateLimitStore) update(apply func(*RateLimitState)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := s.read()
+	if err != nil {
+		return err
+	}
+	apply(&state)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode rate limit state: %w", err)
+	}
+	return writeFileAtomic(s.path, data, 0o600)
+}
+
+// read loads the state file; the caller must hold the file lock.
+func (s *FileRateLimitStore) read() (RateLimitState, error) {
+	var state RateLimitState
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read rate limit file: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to decode rate limit file: %w", err)
+	}
+	return state, nil
+}
+
+func (s *FileRateLimitStore) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rate limit lock file: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock rate limit file: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
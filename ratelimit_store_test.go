@@ -0,0 +1,95 @@
+package threads
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileRateLimitStore_SharesRateLimitBetweenLimiters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	storeA, err := NewFileRateLimitStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storeB, err := NewFileRateLimitStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewRateLimiter(&RateLimiterConfig{Store: storeA})
+	b := NewRateLimiter(&RateLimiterConfig{Store: storeB})
+
+	if b.ShouldWait() {
+		t.Fatal("expected an empty store not to block requests")
+	}
+
+	until := time.Now().Add(time.Hour).Truncate(time.Second)
+	a.MarkRateLimited(until)
+
+	if !b.ShouldWait() || !b.IsRateLimited() {
+		t.Fatal("expected a rate limit recorded by another limiter to be honoured")
+	}
+	if reset := b.GetStatus().ResetTime; reset.Before(until) || reset.After(until.Add(time.Second)) {
+		t.Errorf("expected reset time %s, got %s", until, reset)
+	}
+
+	// An earlier reset must not shorten the shared wait.
+	b.MarkRateLimited(time.Now().Add(time.Minute))
+	state, err := storeA.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.RateLimitedUntil.Equal(until) {
+		t.Errorf("expected shared reset to stay at %s, got %s", until, state.RateLimitedUntil)
+	}
+}
+
+func TestFileRateLimitStore_SharesExhaustedWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	storeA, _ := NewFileRateLimitStore(path)
+	storeB, _ := NewFileRateLimitStore(path)
+	a := NewRateLimiter(&RateLimiterConfig{Store: storeA})
+	b := NewRateLimiter(&RateLimiterConfig{Store: storeB})
+
+	a.UpdateFromHeaders(&RateLimitInfo{Limit: 200, Remaining: 5, Reset: time.Now().Add(10 * time.Minute)})
+	if b.ShouldWait() {
+		t.Fatal("expected a window with requests remaining not to block requests")
+	}
+
+	a.UpdateFromHeaders(&RateLimitInfo{Limit: 200, Remaining: 0, Reset: time.Now().Add(10 * time.Minute)})
+	if wait, d := storeB.ShouldWait(); !wait || d <= 9*time.Minute {
+		t.Errorf("expected the store to report a wait until the window resets, got %v %v", wait, d)
+	}
+	if !b.ShouldWait() {
+		t.Error("expected an exhausted window recorded by another limiter to be honoured")
+	}
+}
+
+func TestRateLimiter_DefaultStoreIsPrivate(t *testing.T) {
+	a := NewRateLimiter(&RateLimiterConfig{})
+	b := NewRateLimiter(&RateLimiterConfig{})
+
+	a.MarkRateLimited(time.Now().Add(time.Hour))
+	if b.ShouldWait() {
+		t.Error("expected limiters without a shared store to be independent")
+	}
+
+	a.Reset()
+	if a.ShouldWait() {
+		t.Error("expected Reset to clear the default store")
+	}
+}
+
+func TestClient_UsesConfiguredRateLimitStore(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	config := testClientConfig(t, jsonHandler(200, `{"id":"12345"}`))
+	config.RateLimitStore = store
+	client := testClientWithConfig(t, config)
+
+	if err := store.MarkRateLimited(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if !client.IsRateLimited() {
+		t.Error("expected the client to honour its shared rate limit store")
+	}
+}
@@ -0,0 +1,90 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+type retryConfigKey struct{}
+
+// WithRetryConfig returns a context that makes requests issued with it use
+// config instead of Config.RetryConfig. Use it to change retries for a single
+// call, for example to disable them with MaxRetries 0 in a latency-sensitive
+// handler or to retry harder in a background job:
+//
+//	ctx := threads.WithRetryConfig(ctx, &threads.RetryConfig{MaxRetries: 0})
+//	post, err := client.CreateTextPost(ctx, content)
+//
+// Zero InitialDelay, MaxDelay and BackoffFactor fall back to the client's
+// configuration.
+func WithRetryConfig(ctx context.Context, config *RetryConfig) context.Context {
+	return context.WithValue(ctx, retryConfigKey{}, config)
+}
+
+// retryConfigFor returns the retry configuration for a request: the
+// per-request override from opts or its context merged over the client's.
+func (h *HTTPClient) retryConfigFor(opts *RequestOptions) RetryConfig {
+	base := RetryConfig{}
+	if h.retryConfig != nil {
+		base = *h.retryConfig
+	}
+
+	override := opts.RetryConfig
+	if override == nil && opts.Context != nil {
+		override, _ = opts.Context.Value(retryConfigKey{}).(*RetryConfig)
+	}
+	if override == nil {
+		return base
+	}
+
+	merged := *override
+	if merged.MaxRetries < 0 {
+		merged.MaxRetries = 0
+	}
+	if merged.InitialDelay <= 0 {
+		merged.InitialDelay = base.InitialDelay
+	}
+	if merged.MaxDelay <= 0 {
+		merged.MaxDelay = base.MaxDelay
+	}
+	if merged.BackoffFactor <= 0 {
+		merged.BackoffFactor = base.BackoffFactor
+	}
+	return merged
+}
+
+// retryDelay returns how long to wait before retrying after err, where
+// backoff is the current exponential backoff delay. A RateLimitError's
+// RetryAfter takes precedence over backoff; ok is false when the API asks
+// for a longer wait than MaxDelay, in which case the error should be
+// returned to the caller instead.
+func (rc RetryConfig) retryDelay(err error, backoff time.Duration) (delay time.Duration, ok bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RetryAfter > 0 {
+		if rc.MaxDelay > 0 && rateLimitErr.RetryAfter > rc.MaxDelay {
+			return 0, false
+		}
+		// Never retry before the server allows it; spread retries after it.
+		return rateLimitErr.RetryAfter + rc.jitter(rateLimitErr.RetryAfter), true
+	}
+	return backoff - rc.jitter(backoff), true
+}
+
+// nextBackoff returns the backoff delay following backoff.
+func (rc RetryConfig) nextBackoff(backoff time.Duration) time.Duration {
+	next := time.Duration(float64(backoff) * rc.BackoffFactor)
+	if rc.MaxDelay > 0 && next > rc.MaxDelay {
+		next = rc.MaxDelay
+	}
+	return next
+}
+
+// jitter returns a random duration between 0 and Jitter*d.
+func (rc RetryConfig) jitter(d time.Duration) time.Duration {
+	if rc.Jitter <= 0 || d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Float64() * rc.Jitter * float64(d))
+}
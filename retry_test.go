@@ -0,0 +1,167 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_RetryHonorsRetryAfter(t *testing.T) {
+	var attempts int32
+	var firstAt, secondAt atomic.Value
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAt.Store(time.Now())
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":4}}`))
+			return
+		}
+		secondAt.Store(time.Now())
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries:    2,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      5 * time.Second,
+		BackoffFactor: 2.0,
+	})
+
+	if _, err := httpClient.Do(&RequestOptions{Method: "GET", Path: "/test"}, "token"); err != nil {
+		t.Fatalf("expected success after retry, got %v", err)
+	}
+	if gap := secondAt.Load().(time.Time).Sub(firstAt.Load().(time.Time)); gap < time.Second {
+		t.Errorf("expected the retry to wait for Retry-After, waited %s", gap)
+	}
+}
+
+func TestHTTPClient_RetryAfterBeyondMaxDelayIsReturned(t *testing.T) {
+	var attempts int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":4}}`))
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      50 * time.Millisecond,
+		BackoffFactor: 2.0,
+	})
+
+	_, err := httpClient.Do(&RequestOptions{Method: "GET", Path: "/test"}, "token")
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) || rateLimitErr.RetryAfter != time.Hour {
+		t.Fatalf("expected RateLimitError with a 1h RetryAfter, got %v", err)
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("expected no retry when Retry-After exceeds MaxDelay, got %d attempts", n)
+	}
+}
+
+func TestCreateErrorFromResponse_GraphThrottlingCodes(t *testing.T) {
+	for _, tc := range []struct {
+		status, code int
+	}{
+		{400, ErrorCodeAppRateLimit},
+		{400, ErrorCodeUserRateLimit},
+		{403, ErrorCodePageRateLimit},
+		{400, ErrorCodeCustomRateLimit},
+		{400, 80002},
+	} {
+		resp := &Response{
+			StatusCode: tc.status,
+			Body:       []byte(`{"error":{"message":"Request limit reached","code":` + strconv.Itoa(tc.code) + `}}`),
+			Usage: &APIUsage{BusinessUseCase: map[string][]BusinessUseCaseUsage{
+				"1": {{Type: "threads", EstimatedTimeToRegainAccess: 2 * time.Minute}},
+			}},
+		}
+
+		httpClient := &HTTPClient{logger: &noopLogger{}}
+		err := httpClient.createErrorFromResponse(resp)
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			t.Errorf("HTTP %d code %d: expected RateLimitError, got %T", tc.status, tc.code, err)
+			continue
+		}
+		if rateLimitErr.RetryAfter != 2*time.Minute {
+			t.Errorf("code %d: expected RetryAfter from usage headers, got %s", tc.code, rateLimitErr.RetryAfter)
+		}
+
+		client := &Client{}
+		if err := client.handleAPIError(resp); !IsRateLimitError(err) {
+			t.Errorf("handleAPIError HTTP %d code %d: expected RateLimitError, got %T", tc.status, tc.code, err)
+		}
+	}
+
+	httpClient := &HTTPClient{logger: &noopLogger{}}
+	err := httpClient.createErrorFromResponse(&Response{
+		StatusCode: 403,
+		Body:       []byte(`{"error":{"message":"Permission denied","code":10}}`),
+	})
+	if !IsAuthenticationError(err) {
+		t.Errorf("expected other 403 errors to stay AuthenticationError, got %T", err)
+	}
+}
+
+func TestRetryConfig_RetryDelayJitter(t *testing.T) {
+	rc := RetryConfig{MaxDelay: time.Minute, Jitter: 0.5}
+	for i := 0; i < 50; i++ {
+		delay, ok := rc.retryDelay(errors.New("boom"), time.Second)
+		if !ok || delay < 500*time.Millisecond || delay > time.Second {
+			t.Fatalf("expected backoff jittered down to [0.5s, 1s], got %s", delay)
+		}
+
+		delay, ok = rc.retryDelay(NewRateLimitError(429, "slow down", "", 10*time.Second), time.Second)
+		if !ok || delay < 10*time.Second || delay > 15*time.Second {
+			t.Fatalf("expected Retry-After jittered up to [10s, 15s], got %s", delay)
+		}
+	}
+
+	if delay, _ := (RetryConfig{}).retryDelay(errors.New("boom"), time.Second); delay != time.Second {
+		t.Errorf("expected no jitter by default on a bare RetryConfig, got %s", delay)
+	}
+}
+
+func TestWithRetryConfig_OverridesPerCall(t *testing.T) {
+	var attempts int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"message":"Internal error","code":2,"is_transient":true}}`))
+	}
+
+	httpClient := newTestHTTPClient(t, http.HandlerFunc(handler), &RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      50 * time.Millisecond,
+		BackoffFactor: 2.0,
+	})
+
+	ctx := WithRetryConfig(context.Background(), &RetryConfig{MaxRetries: 0})
+	if _, err := httpClient.GETWithContext(ctx, "/test", nil, "token"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 1 {
+		t.Errorf("expected retries disabled for this call, got %d attempts", n)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	opts := &RequestOptions{Method: "GET", Path: "/test", RetryConfig: &RetryConfig{MaxRetries: 1}}
+	if _, err := httpClient.Do(opts, "token"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("expected one retry from RequestOptions.RetryConfig, got %d attempts", n)
+	}
+}
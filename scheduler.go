@@ -0,0 +1,546 @@
+package threads
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ScheduledJobStatus is the state of a scheduled job.
+type ScheduledJobStatus string
+
+// Scheduled job states
+const (
+	ScheduledJobPending   ScheduledJobStatus = "pending"   // Waiting to be published
+	ScheduledJobPublished ScheduledJobStatus = "published" // Published; PostID is set
+	ScheduledJobFailed    ScheduledJobStatus = "failed"    // Gave up; Error describes the last failure
+)
+
+// ScheduledJob is a post scheduled for publishing by a Scheduler.
+type ScheduledJob struct {
+	ID        string             `json:"id"`
+	Draft     PostDraft          `json:"-"` // Encoded as "draft_type" and "draft"
+	PublishAt time.Time          `json:"publish_at"`
+	Status    ScheduledJobStatus `json:"status"`
+
+	// PostID is the published post, set once Status is published.
+	PostID PostID `json:"post_id,omitempty"`
+
+	// Error is the last failure, kept while a pending job waits to be
+	// retried and when the job failed.
+	Error string `json:"error,omitempty"`
+
+	// Attempts counts failed publishing attempts.
+	Attempts int `json:"attempts"`
+
+	// NextAttemptAt delays a pending job past PublishAt after a transient
+	// failure or while the publishing quota is used up.
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// dueAt returns when a pending job should next be published.
+func (j *ScheduledJob) dueAt() time.Time {
+	if j.NextAttemptAt.After(j.PublishAt) {
+		return j.NextAttemptAt
+	}
+	return j.PublishAt
+}
+
+// Draft types used in the JSON encoding of a ScheduledJob
+const (
+	scheduledDraftText     = "text"
+	scheduledDraftImage    = "image"
+	scheduledDraftVideo    = "video"
+	scheduledDraftCarousel = "carousel"
+)
+
+// scheduledJobAlias has the fields of ScheduledJob without its methods.
+type scheduledJobAlias ScheduledJob
+
+type scheduledJobJSON struct {
+	*scheduledJobAlias
+	DraftType string          `json:"draft_type"`
+	Draft     json.RawMessage `json:"draft"`
+}
+
+// MarshalJSON encodes the job, recording the draft's content type.
+func (j *ScheduledJob) MarshalJSON() ([]byte, error) {
+	var draftType string
+	switch j.Draft.(type) {
+	case *TextPostContent:
+		draftType = scheduledDraftText
+	case *ImagePostContent:
+		draftType = scheduledDraftImage
+	case *VideoPostContent:
+		draftType = scheduledDraftVideo
+	case *CarouselPostContent:
+		draftType = scheduledDraftCarousel
+	default:
+		return nil, fmt.Errorf("unsupported draft type for scheduled job: %T", j.Draft)
+	}
+
+	draft, err := json.Marshal(j.Draft)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(scheduledJobJSON{scheduledJobAlias: (*scheduledJobAlias)(j), DraftType: draftType, Draft: draft})
+}
+
+// UnmarshalJSON decodes a job encoded by MarshalJSON.
+func (j *ScheduledJob) UnmarshalJSON(data []byte) error {
+	aux := scheduledJobJSON{scheduledJobAlias: (*scheduledJobAlias)(j)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	switch aux.DraftType {
+	case scheduledDraftText:
+		j.Draft = &TextPostContent{}
+	case scheduledDraftImage:
+		j.Draft = &ImagePostContent{}
+	case scheduledDraftVideo:
+		j.Draft = &VideoPostContent{}
+	case scheduledDraftCarousel:
+		j.Draft = &CarouselPostContent{}
+	default:
+		return fmt.Errorf("unsupported draft type for scheduled job: %q", aux.DraftType)
+	}
+	return json.Unmarshal(aux.Draft, j.Draft)
+}
+
+// SchedulerOptions configures a Scheduler. Zero values use the
+// DefaultScheduler* constants.
+type SchedulerOptions struct {
+	// Store persists the jobs (default: a new MemoryScheduleStore).
+	Store ScheduleStore
+
+	// PollInterval caps the time between checks for due jobs, so jobs
+	// added to the store by other processes are picked up.
+	PollInterval time.Duration
+
+	// MaxAttempts is the number of attempts made to publish a job whose
+	// attempts fail with transient errors.
+	MaxAttempts int
+
+	// RetryDelay is the delay before retrying a failed attempt. It doubles
+	// with each further attempt, up to MaxRetryDelay.
+	RetryDelay time.Duration
+
+	// MaxRetryDelay caps the delay between attempts.
+	MaxRetryDelay time.Duration
+
+	// QuotaGuard configures the scheduler's check of the publishing quotas
+	// before each attempt, so due jobs wait for quota instead of being
+	// rejected by the API. The check uses its own cache of the limits and
+	// does not change the client's quota guard.
+	QuotaGuard *QuotaGuardOptions
+
+	// OnJobDone is called when a job is published or fails for good.
+	OnJobDone func(job *ScheduledJob)
+
+	// OnError is called when the worker cannot read or update the store.
+	OnError func(err error)
+}
+
+// Scheduler publishes post drafts at scheduled times. Jobs are persisted
+// through a ScheduleStore, and a worker started with Start publishes each
+// job when it is due using CreateTextPost, CreateImagePost, CreateVideoPost
+// or CreateCarouselPost, or ReplyWithDraft for drafts with ReplyTo set.
+//
+// Each job is published under an idempotency key derived from its ID (see
+// WithIdempotencyKey), so a job interrupted by a crash is not published
+// twice when the worker restarts, provided the client's IdempotencyStore is
+// durable too. Run a single worker per store.
+type Scheduler struct {
+	client *Client
+	store  ScheduleStore
+	opts   SchedulerOptions
+	quota  *quotaGuard
+	wake   chan struct{}
+
+	mu     sync.Mutex
+	active string // ID of the job being published
+	worker *schedulerWorker
+
+	runMu sync.Mutex // Serializes runs over the due jobs
+}
+
+type schedulerWorker struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScheduler creates a scheduler that publishes with client.
+func NewScheduler(client *Client, opts *SchedulerOptions) (*Scheduler, error) {
+	if client == nil {
+		return nil, NewValidationError(400, "Client is required", "NewScheduler needs a client to publish with", "client")
+	}
+
+	o := SchedulerOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Store == nil {
+		o.Store = NewMemoryScheduleStore()
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultSchedulerPollInterval
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultSchedulerMaxAttempts
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = DefaultSchedulerRetryDelay
+	}
+	if o.MaxRetryDelay <= 0 {
+		o.MaxRetryDelay = DefaultSchedulerMaxRetryDelay
+	}
+
+	return &Scheduler{
+		client: client,
+		store:  o.Store,
+		opts:   o,
+		quota:  newQuotaGuard(o.QuotaGuard),
+		wake:   make(chan struct{}, 1),
+	}, nil
+}
+
+// Schedule validates draft and stores it as a pending job to be published
+// at publishAt. A publishAt in the past publishes the draft as soon as the
+// worker runs. The draft is copied; carousel children must still be valid
+// containers when the job runs, and containers expire after 24 hours.
+// Local media must be given by path, which must still exist when the job
+// runs; readers cannot be stored.
+func (s *Scheduler) Schedule(ctx context.Context, draft PostDraft, publishAt time.Time) (*ScheduledJob, error) {
+	content, err := replyContent(draft)
+	if err != nil {
+		return nil, err
+	}
+	if hasMediaReader(content) {
+		return nil, NewValidationError(400, "Media reader cannot be scheduled",
+			"Scheduled posts must give local media by path", "media")
+	}
+	if _, err := s.client.contentFlow(ctx, content); err != nil {
+		return nil, err
+	}
+
+	id, err := newScheduledJobID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &ScheduledJob{
+		ID:        id,
+		Draft:     withReplyTo(content, replyTarget(content)).(PostDraft),
+		PublishAt: publishAt,
+		Status:    ScheduledJobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.Put(job); err != nil {
+		return nil, fmt.Errorf("failed to store scheduled job: %w", err)
+	}
+
+	// Let a running worker reconsider when to wake up
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Job returns the job with the given ID, including its status.
+func (s *Scheduler) Job(id string) (*ScheduledJob, error) {
+	job, err := s.store.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scheduled job: %w", err)
+	}
+	if job == nil {
+		return nil, NewValidationError(404, "Scheduled job not found", fmt.Sprintf("No scheduled job with ID %s", id), "id")
+	}
+	return job, nil
+}
+
+// Jobs returns all jobs, ordered by PublishAt.
+func (s *Scheduler) Jobs() ([]*ScheduledJob, error) {
+	jobs, err := s.store.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// Cancel removes a pending job. Jobs that were published or failed, or
+// that are being published, cannot be cancelled.
+func (s *Scheduler) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.Job(id)
+	if err != nil {
+		return err
+	}
+	if s.active == id {
+		return NewValidationError(400, "Scheduled job cannot be cancelled",
+			fmt.Sprintf("Job %s is being published", id), "id")
+	}
+	if job.Status != ScheduledJobPending {
+		return NewValidationError(400, "Scheduled job cannot be cancelled",
+			fmt.Sprintf("Job %s is %s", id, job.Status), "id")
+	}
+	if err := s.store.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete scheduled job: %w", err)
+	}
+	return nil
+}
+
+// Start starts a background worker that publishes jobs as they become due.
+// The worker runs until ctx is cancelled or Stop is called. It returns an
+// error if the worker is already running.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.worker != nil {
+		return fmt.Errorf("scheduler is already running")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &schedulerWorker{cancel: cancel, done: make(chan struct{})}
+	s.worker = w
+
+	go s.run(ctx, w)
+	return nil
+}
+
+// Stop stops the worker, if running, and waits for it to exit. A job being
+// published is interrupted and stays pending.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	w := s.worker
+	s.worker = nil
+	s.mu.Unlock()
+
+	if w == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+}
+
+// RunDue publishes the jobs that are due now and returns once they are
+// done, for callers that drive the scheduler from their own loop or cron
+// instead of calling Start.
+func (s *Scheduler) RunDue(ctx context.Context) error {
+	_, err := s.runDue(ctx)
+	return err
+}
+
+func (s *Scheduler) run(ctx context.Context, w *schedulerWorker) {
+	defer func() {
+		s.mu.Lock()
+		if s.worker == w {
+			s.worker = nil
+		}
+		s.mu.Unlock()
+		close(w.done)
+	}()
+
+	for {
+		next, err := s.runDue(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if s.client.config.Logger != nil {
+				s.client.config.Logger.Warn("Scheduler could not process due jobs", "error", err.Error())
+			}
+			if s.opts.OnError != nil {
+				s.opts.OnError(err)
+			}
+		}
+
+		wait := s.opts.PollInterval
+		if !next.IsZero() {
+			wait = min(wait, max(time.Until(next), 0))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// runDue publishes the due pending jobs in order and returns when the next
+// pending job is due, or the zero time if there is none.
+func (s *Scheduler) runDue(ctx context.Context) (time.Time, error) {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	jobs, err := s.store.List()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to list scheduled jobs: %w", err)
+	}
+
+	var next time.Time
+	for _, job := range jobs {
+		if job.Status != ScheduledJobPending {
+			continue
+		}
+		if due := job.dueAt(); due.After(time.Now()) {
+			if next.IsZero() || due.Before(next) {
+				next = due
+			}
+			continue
+		}
+
+		job, err := s.runJob(ctx, job.ID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if job != nil && job.Status == ScheduledJobPending {
+			if due := job.dueAt(); next.IsZero() || due.Before(next) {
+				next = due
+			}
+		}
+	}
+	return next, nil
+}
+
+// runJob makes one attempt to publish the job and records the outcome. It
+// returns the updated job, or nil if the job is no longer pending.
+func (s *Scheduler) runJob(ctx context.Context, id string) (*ScheduledJob, error) {
+	s.mu.Lock()
+	job, err := s.store.Get(id)
+	if err != nil || job == nil || job.Status != ScheduledJobPending {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.active = id
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.active = ""
+		s.mu.Unlock()
+	}()
+
+	post, err := s.publish(ctx, job)
+	if ctx.Err() != nil {
+		// Stopped; the idempotency key makes the next attempt safe
+		return nil, ctx.Err()
+	}
+
+	now := time.Now()
+	job.UpdatedAt = now
+	switch {
+	case err == nil:
+		job.Status = ScheduledJobPublished
+		job.PostID = ConvertToPostID(post.ID)
+		job.Error = ""
+	case IsQuotaExceededError(err):
+		// Waiting for quota does not use up an attempt
+		var quotaErr *QuotaExceededError
+		errors.As(err, &quotaErr)
+		job.NextAttemptAt = quotaErr.ResetAt
+		if !job.NextAttemptAt.After(now) {
+			job.NextAttemptAt = now.Add(s.opts.RetryDelay)
+		}
+		job.Error = err.Error()
+	default:
+		job.Attempts++
+		job.Error = err.Error()
+		if (IsTransientError(err) || IsRateLimitError(err)) && job.Attempts < s.opts.MaxAttempts {
+			job.NextAttemptAt = now.Add(s.retryDelay(job.Attempts))
+		} else {
+			job.Status = ScheduledJobFailed
+		}
+	}
+
+	if s.client.config.Logger != nil {
+		s.client.config.Logger.Info("Scheduled job attempted",
+			"job_id", job.ID,
+			"status", string(job.Status),
+			"attempts", job.Attempts)
+	}
+
+	if putErr := s.store.Put(job); putErr != nil {
+		return nil, fmt.Errorf("failed to update scheduled job %s: %w", job.ID, putErr)
+	}
+	if job.Status != ScheduledJobPending && s.opts.OnJobDone != nil {
+		s.opts.OnJobDone(job)
+	}
+	return job, nil
+}
+
+// retryDelay returns the delay before the attempt after the given number of
+// failed attempts: RetryDelay doubled for each attempt after the first, up
+// to MaxRetryDelay.
+func (s *Scheduler) retryDelay(attempts int) time.Duration {
+	delay := s.opts.RetryDelay
+	for i := 1; i < attempts; i++ {
+		if delay > s.opts.MaxRetryDelay/2 {
+			return s.opts.MaxRetryDelay
+		}
+		delay *= 2
+	}
+	return min(delay, s.opts.MaxRetryDelay)
+}
+
+// publish checks the publishing quota and publishes the job's draft with
+// the matching Create*Post method.
+func (s *Scheduler) publish(ctx context.Context, job *ScheduledJob) (*Post, error) {
+	class := publishQuota(job.Draft)
+	if err := s.client.checkQuotaWith(ctx, s.quota, class); err != nil {
+		return nil, err
+	}
+
+	post, err := s.publishDraft(WithIdempotencyKey(ctx, "scheduled/"+job.ID), job.Draft)
+	if err == nil {
+		s.quota.record(class)
+	}
+	return post, err
+}
+
+// publishDraft publishes draft with the matching Create*Post method.
+func (s *Scheduler) publishDraft(ctx context.Context, draft PostDraft) (*Post, error) {
+	if replyTarget(draft) != "" {
+		return s.client.ReplyWithDraft(ctx, draft)
+	}
+	switch v := draft.(type) {
+	case *TextPostContent:
+		return s.client.CreateTextPost(ctx, v)
+	case *ImagePostContent:
+		return s.client.CreateImagePost(ctx, v)
+	case *VideoPostContent:
+		return s.client.CreateVideoPost(ctx, v)
+	case *CarouselPostContent:
+		return s.client.CreateCarouselPost(ctx, v)
+	default:
+		return nil, NewValidationError(400, "Unsupported content type",
+			fmt.Sprintf("unsupported content type for scheduled job: %T", draft), "draft")
+	}
+}
+
+// newScheduledJobID returns a random job ID.
+func newScheduledJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,229 @@
+package threads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// ScheduleStore persists scheduled jobs for a Scheduler. Use a durable
+// implementation so scheduled posts survive restarts. Implementations must
+// be safe for concurrent use.
+type ScheduleStore interface {
+	// Get returns the job with the given ID, or nil if there is none.
+	Get(id string) (*ScheduledJob, error)
+
+	// Put creates or replaces the job with job.ID.
+	Put(job *ScheduledJob) error
+
+	// Delete removes the job with the given ID. Deleting a job that does
+	// not exist is not an error.
+	Delete(id string) error
+
+	// List returns all jobs ordered by PublishAt.
+	List() ([]*ScheduledJob, error)
+}
+
+// sortScheduledJobs orders jobs by PublishAt, then by ID.
+func sortScheduledJobs(jobs []*ScheduledJob) {
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].PublishAt.Equal(jobs[j].PublishAt) {
+			return jobs[i].PublishAt.Before(jobs[j].PublishAt)
+		}
+		return jobs[i].ID < jobs[j].ID
+	})
+}
+
+// MemoryScheduleStore keeps scheduled jobs in memory. It is the default
+// ScheduleStore; jobs are lost when the process exits.
+type MemoryScheduleStore struct {
+	mu   sync.Mutex
+	jobs map[string]ScheduledJob
+}
+
+// NewMemoryScheduleStore creates an empty in-memory schedule store.
+func NewMemoryScheduleStore() *MemoryScheduleStore {
+	return &MemoryScheduleStore{jobs: make(map[string]ScheduledJob)}
+}
+
+// Get returns a copy of the job with the given ID, or nil if there is none.
+func (s *MemoryScheduleStore) Get(id string) (*ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// Put stores a copy of job.
+func (s *MemoryScheduleStore) Put(job *ScheduledJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+// Delete removes the job with the given ID.
+func (s *MemoryScheduleStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// List returns copies of all jobs ordered by PublishAt.
+func (s *MemoryScheduleStore) List() ([]*ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*ScheduledJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		job := job
+		jobs = append(jobs, &job)
+	}
+	sortScheduledJobs(jobs)
+	return jobs, nil
+}
+
+// FileScheduleStore keeps scheduled jobs in a JSON file so they survive
+// restarts. Updates hold an exclusive lock on a sidecar "<path>.lock" file
+// and replace the jobs file atomically.
+type FileScheduleStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileScheduleStore creates a store backed by the file at path. The file
+// is created when the first job is stored.
+func NewFileScheduleStore(path string) (*FileScheduleStore, error) {
+	if path == "" {
+		return nil, NewValidationError(400, "Schedule file path is required", "FileScheduleStore needs a non-empty path", "path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+	return &FileScheduleStore{path: path}, nil
+}
+
+// Get returns the job with the given ID, or nil if there is none.
+func (s *FileScheduleStore) Get(id string) (*ScheduledJob, error) {
+	jobs, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+// Put creates or replaces the job with job.ID.
+func (s *FileScheduleStore) Put(job *ScheduledJob) error {
+	return s.update(func(jobs []*ScheduledJob) []*ScheduledJob {
+		for i, existing := range jobs {
+			if existing.ID == job.ID {
+				jobs[i] = job
+				return jobs
+			}
+		}
+		return append(jobs, job)
+	})
+}
+
+// Delete removes the job with the given ID.
+func (s *FileScheduleStore) Delete(id string) error {
+	return s.update(func(jobs []*ScheduledJob) []*ScheduledJob {
+		for i, existing := range jobs {
+			if existing.ID == id {
+				return append(jobs[:i], jobs[i+1:]...)
+			}
+		}
+		return jobs
+	})
+}
+
+// List returns all jobs ordered by PublishAt.
+func (s *FileScheduleStore) List() ([]*ScheduledJob, error) {
+	jobs, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	sortScheduledJobs(jobs)
+	return jobs, nil
+}
+
+func (s *FileScheduleStore) load() ([]*ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.read()
+}
+
+func (s *FileScheduleStore) update(apply func([]*ScheduledJob) []*ScheduledJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	jobs, err := s.read()
+	if err != nil {
+		return err
+	}
+	jobs = apply(jobs)
+	sortScheduledJobs(jobs)
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled jobs: %w", err)
+	}
+	return writeFileAtomic(s.path, data, 0o600)
+}
+
+// read loads the jobs file; the caller must hold the file lock.
+func (s *FileScheduleStore) read() ([]*ScheduledJob, error) {
+	var jobs []*ScheduledJob
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return jobs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule file: %w", err)
+	}
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule file: %w", err)
+	}
+	return jobs, nil
+}
+
+func (s *FileScheduleStore) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open schedule lock file: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock schedule file: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,257 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_PublishesDueJobs(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	scheduler, err := NewScheduler(client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	due, err := scheduler.Schedule(ctx, &TextPostContent{Text: "good morning"}, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	later, err := scheduler.Schedule(ctx, &ImagePostContent{ImageURL: "https://example.com/a.png"}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scheduler.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := scheduler.Job(due.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != ScheduledJobPublished || job.PostID != "p-c1" {
+		t.Errorf("expected the due job to be published as p-c1, got %+v", job)
+	}
+	if job, _ := scheduler.Job(later.ID); job.Status != ScheduledJobPending {
+		t.Errorf("expected the later job to stay pending, got %s", job.Status)
+	}
+	if creates, _ := srv.counts(); creates != 1 {
+		t.Errorf("expected one container, got %d", creates)
+	}
+
+	if err := scheduler.Cancel(due.ID); !IsValidationError(err) {
+		t.Errorf("expected a published job not to be cancellable, got %v", err)
+	}
+	if err := scheduler.Cancel(later.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scheduler.Job(later.ID); !IsValidationError(err) {
+		t.Errorf("expected the cancelled job to be gone, got %v", err)
+	}
+}
+
+func TestScheduler_RetriesTransientFailures(t *testing.T) {
+	srv := &publishServer{}
+	var mu sync.Mutex
+	transientFailures := 1
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fail := r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/threads") && transientFailures > 0
+		if fail {
+			transientFailures--
+		}
+		mu.Unlock()
+
+		if fail {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Please retry","code":2,"is_transient":true}}`))
+			return
+		}
+		srv.ServeHTTP(w, r)
+	})
+	config := testClientConfig(t, handler)
+	config.RetryConfig = &RetryConfig{MaxRetries: 0, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1}
+	client := testClientWithConfig(t, config)
+
+	var done []*ScheduledJob
+	scheduler, err := NewScheduler(client, &SchedulerOptions{
+		RetryDelay: time.Millisecond,
+		OnJobDone:  func(job *ScheduledJob) { done = append(done, job) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	job, err := scheduler.Schedule(ctx, &TextPostContent{Text: "retry me"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if job, _ = scheduler.Job(job.ID); job.Status != ScheduledJobPending || job.Attempts != 1 || job.Error == "" {
+		t.Fatalf("expected the job to wait for a retry, got %+v", job)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if err := scheduler.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if job, _ = scheduler.Job(job.ID); job.Status != ScheduledJobPublished || job.Error != "" {
+		t.Errorf("expected the retry to publish the job, got %+v", job)
+	}
+	if len(done) != 1 || done[0].ID != job.ID {
+		t.Errorf("expected one OnJobDone call, got %v", done)
+	}
+}
+
+func TestScheduler_FailsOnPermanentError(t *testing.T) {
+	srv := &publishServer{failCreate: 1}
+	client := publishTestClient(t, srv)
+	scheduler, err := NewScheduler(client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	job, err := scheduler.Schedule(ctx, &TextPostContent{Text: "rejected"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if job, _ = scheduler.Job(job.ID); job.Status != ScheduledJobFailed || job.Attempts != 1 || job.Error == "" {
+		t.Errorf("expected the job to fail without retrying, got %+v", job)
+	}
+}
+
+func TestScheduler_WaitsForQuota(t *testing.T) {
+	srv := &quotaServer{postsUsed: 250}
+	client := testClient(t, srv)
+	scheduler, err := NewScheduler(client, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	job, err := scheduler.Schedule(ctx, &TextPostContent{Text: "over quota"}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scheduler.RunDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	job, _ = scheduler.Job(job.ID)
+	if job.Status != ScheduledJobPending || job.Attempts != 0 || !job.NextAttemptAt.After(time.Now()) {
+		t.Errorf("expected the job to wait for quota without using an attempt, got %+v", job)
+	}
+	if calls := srv.calls(); len(calls) != 0 {
+		t.Errorf("expected nothing to be published, got %v", calls)
+	}
+	if client.getQuotaGuard() != nil {
+		t.Error("expected the scheduler not to enable the client's quota guard")
+	}
+}
+
+func TestScheduler_RetryDelayIsCapped(t *testing.T) {
+	scheduler, err := NewScheduler(newBareClient(t), &SchedulerOptions{RetryDelay: time.Second, MaxRetryDelay: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for attempts, want := range map[int]time.Duration{1: time.Second, 3: 4 * time.Second, 40: time.Hour, 1000: time.Hour} {
+		if got := scheduler.retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestScheduler_StartPublishesInBackground(t *testing.T) {
+	srv := &publishServer{}
+	client := publishTestClient(t, srv)
+	done := make(chan *ScheduledJob, 1)
+	scheduler, err := NewScheduler(client, &SchedulerOptions{
+		Store:     NewMemoryScheduleStore(),
+		OnJobDone: func(job *ScheduledJob) { done <- job },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scheduler.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Stop()
+	if err := scheduler.Start(context.Background()); err == nil {
+		t.Error("expected a second Start to fail")
+	}
+
+	if _, err := scheduler.Schedule(context.Background(), &TextPostContent{Text: "soon"}, time.Now().Add(20*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case job := <-done:
+		if job.Status != ScheduledJobPublished {
+			t.Errorf("expected the job to be published, got %+v", job)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the scheduled job")
+	}
+}
+
+func TestFileScheduleStore_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.json")
+	store, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publishAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	jobs := []*ScheduledJob{
+		{ID: "b", Draft: &VideoPostContent{VideoURL: "https://example.com/v.mp4"}, PublishAt: publishAt.Add(time.Minute), Status: ScheduledJobPending},
+		{ID: "a", Draft: &TextPostContent{Text: "hi", ReplyTo: "123"}, PublishAt: publishAt, Status: ScheduledJobPending},
+		{ID: "c", Draft: &CarouselPostContent{Children: []string{"1", "2"}}, PublishAt: publishAt, Status: ScheduledJobFailed, Error: "boom"},
+	}
+	for _, job := range jobs {
+		if err := store.Put(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Delete("c"); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileScheduleStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := reopened.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 2 || loaded[0].ID != "a" || loaded[1].ID != "b" {
+		t.Fatalf("expected jobs a and b in PublishAt order, got %+v", loaded)
+	}
+	if text, ok := loaded[0].Draft.(*TextPostContent); !ok || text.Text != "hi" || text.ReplyTo != "123" {
+		t.Errorf("expected the text draft to round-trip, got %#v", loaded[0].Draft)
+	}
+	if _, ok := loaded[1].Draft.(*VideoPostContent); !ok || !loaded[1].PublishAt.Equal(publishAt.Add(time.Minute)) {
+		t.Errorf("expected the video draft to round-trip, got %+v", loaded[1])
+	}
+
+	if job, err := reopened.Get("missing"); err != nil || job != nil {
+		t.Errorf("expected no job, got %v, %v", job, err)
+	}
+}
@@ -18,6 +18,11 @@ func (c *Client) KeywordSearch(ctx context.Context, query string, opts *SearchOp
 		return nil, err
 	}
 
+	// Fail fast if the quota guard knows the search quota is used up
+	if err := c.checkQuota(ctx, EndpointSearch); err != nil {
+		return nil, err
+	}
+
 	// Build query parameters according to API documentation
 	params := url.Values{
 		"q":      {query},
@@ -74,7 +79,7 @@ func (c *Client) KeywordSearch(ctx context.Context, query string, opts *SearchOp
 
 	// Make API call to keyword search endpoint
 	path := "/keyword_search"
-	resp, err := c.httpClient.GET(path, params, c.getAccessTokenSafe())
+	resp, err := c.httpClient.GETWithContext(ctx, path, params, c.getAccessTokenSafe())
 	if err != nil {
 		return nil, err
 	}
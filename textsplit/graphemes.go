@@ -0,0 +1,60 @@
+package textsplit
+
+import "unicode"
+
+// clusterBounds returns the rune offsets at which grapheme clusters start,
+// followed by len(runes). It approximates Unicode extended grapheme clusters
+// closely enough to keep user-perceived characters intact: combining marks,
+// variation selectors, emoji modifiers and tags stay with their base, ZWJ
+// emoji sequences and regional indicator flags stay together, and CR LF is
+// one cluster.
+func clusterBounds(runes []rune) []int {
+	bounds := make([]int, 0, len(runes)+1)
+	regionalIndicators := 0
+
+	for i, r := range runes {
+		if i == 0 || !joinsPrevious(runes[i-1], r, regionalIndicators) {
+			bounds = append(bounds, i)
+			regionalIndicators = 0
+		}
+		if isRegionalIndicator(r) {
+			regionalIndicators++
+		}
+	}
+	return append(bounds, len(runes))
+}
+
+// joinsPrevious reports whether r continues the cluster that prev belongs
+// to. regionalIndicators is the number of regional indicators in the
+// current cluster.
+func joinsPrevious(prev, r rune, regionalIndicators int) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return true
+	case prev == '\r' || prev == '\n' || r == '\r' || r == '\n':
+		return false
+	case prev == zeroWidthJoiner:
+		return true
+	case isRegionalIndicator(r):
+		return isRegionalIndicator(prev) && regionalIndicators%2 == 1
+	default:
+		return isExtender(r)
+	}
+}
+
+const zeroWidthJoiner = 0x200D
+
+// isExtender reports whether r never starts a cluster of its own.
+func isExtender(r rune) bool {
+	return r == zeroWidthJoiner ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || // Variation selectors
+		(r >= 0xE0100 && r <= 0xE01EF) || // Variation selectors supplement
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // Emoji skin tone modifiers
+		(r >= 0xE0020 && r <= 0xE007F) || // Tags, used by subdivision flags
+		(r >= 0x1160 && r <= 0x11FF) // Hangul medial vowels and final consonants
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
@@ -0,0 +1,278 @@
+// Package textsplit breaks long text into chunks that each fit in a Threads
+// post, for publishing as a thread with Client.CreateThreadChain.
+//
+// Text is split at paragraph, sentence or word boundaries where possible.
+// Links, grapheme clusters and emoji are never split, each chunk stays within
+// the post length and link limits, and spoiler text entities are moved into
+// the chunks they cover:
+//
+//	chunks, err := textsplit.Split(article, &textsplit.Options{Counter: true})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.CreateThreadChain(ctx, textsplit.Drafts(chunks), nil)
+package textsplit
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// urlPattern matches links the way the Threads client counts them.
+var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// Options configures Split.
+type Options struct {
+	// MaxLength is the maximum number of characters (runes) per chunk,
+	// including the counter (default: threads.MaxTextLength).
+	MaxLength int
+
+	// MaxLinks is the maximum number of unique links per chunk
+	// (default: threads.MaxLinks).
+	MaxLinks int
+
+	// Counter appends " (i/n)" to every chunk when the text does not fit in
+	// a single chunk.
+	Counter bool
+
+	// TextEntities are spoiler ranges in the text, with offsets and lengths
+	// in runes. Each is moved into the chunk that contains it, or divided
+	// between chunks if it spans a split.
+	TextEntities []threads.TextEntity
+}
+
+// Chunk is one post-sized piece of text.
+type Chunk struct {
+	Text         string
+	TextEntities []threads.TextEntity
+}
+
+// Split breaks text into chunks according to opts, which may be nil. Text
+// that fits in one chunk is returned as a single chunk without a counter.
+// Leading and trailing whitespace of each chunk is removed. It returns a
+// *threads.ValidationError if a link is too long to fit in a chunk.
+func Split(text string, opts *Options) ([]Chunk, error) {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxLength <= 0 {
+		o.MaxLength = threads.MaxTextLength
+	}
+	if o.MaxLinks <= 0 {
+		o.MaxLinks = threads.MaxLinks
+	}
+
+	s := newSplitter(text)
+
+	spans, err := s.split(o.MaxLength, o.MaxLinks)
+	if err != nil {
+		return nil, err
+	}
+	if !o.Counter || len(spans) <= 1 {
+		return s.chunks(spans, o.TextEntities, false), nil
+	}
+
+	// Reserve room for the counter, growing it until the number of chunks
+	// fits in the reserved digits.
+	for digits := 1; ; digits++ {
+		reserve := len(" (/)") + 2*digits
+		if reserve >= o.MaxLength {
+			return nil, threads.NewValidationError(400, "Text cannot be split",
+				fmt.Sprintf("MaxLength %d leaves no room for the counter", o.MaxLength), "text")
+		}
+
+		spans, err = s.split(o.MaxLength-reserve, o.MaxLinks)
+		if err != nil {
+			return nil, err
+		}
+		if len(strconv.Itoa(len(spans))) <= digits {
+			return s.chunks(spans, o.TextEntities, true), nil
+		}
+	}
+}
+
+// Drafts converts chunks into text post drafts for Client.CreateThreadChain.
+func Drafts(chunks []Chunk) []threads.PostDraft {
+	drafts := make([]threads.PostDraft, len(chunks))
+	for i, chunk := range chunks {
+		drafts[i] = &threads.TextPostContent{Text: chunk.Text, TextEntities: chunk.TextEntities}
+	}
+	return drafts
+}
+
+// span is a chunk's range of runes in the text.
+type span struct {
+	start, end int
+}
+
+// splitter holds the text and the positions where it may be split.
+type splitter struct {
+	runes   []rune
+	n       int    // Length without trailing whitespace
+	bound   []bool // bound[i] is true if a grapheme cluster starts at i
+	inURL   []bool // inURL[i] is true if i is inside a link, after its first rune
+	urls    []span
+	urlKeys []string // Normalized links, for counting unique links
+}
+
+func newSplitter(text string) *splitter {
+	runes := []rune(text)
+	s := &splitter{
+		runes: runes,
+		n:     len(runes),
+		bound: make([]bool, len(runes)+1),
+		inURL: make([]bool, len(runes)+1),
+	}
+	for s.n > 0 && unicode.IsSpace(runes[s.n-1]) {
+		s.n--
+	}
+
+	for _, b := range clusterBounds(runes) {
+		s.bound[b] = true
+	}
+
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		start := len([]rune(text[:loc[0]]))
+		end := start + len([]rune(text[loc[0]:loc[1]]))
+		s.urls = append(s.urls, span{start, end})
+		s.urlKeys = append(s.urlKeys, strings.TrimRight(text[loc[0]:loc[1]], "/"))
+		for i := start + 1; i < end; i++ {
+			s.inURL[i] = true
+		}
+	}
+	return s
+}
+
+// split returns the chunk spans for chunks of at most budget runes with at
+// most maxLinks unique links each.
+func (s *splitter) split(budget, maxLinks int) ([]span, error) {
+	var spans []span
+	start := s.skipSpace(0)
+	for start < s.n {
+		end := s.cut(start, budget, maxLinks)
+		if end <= start {
+			return nil, threads.NewValidationError(400, "Text cannot be split",
+				fmt.Sprintf("Link at character %d does not fit in a chunk of %d characters", start, budget), "text")
+		}
+
+		trimmed := end
+		for trimmed > start && unicode.IsSpace(s.runes[trimmed-1]) {
+			trimmed--
+		}
+		spans = append(spans, span{start, trimmed})
+		start = s.skipSpace(end)
+	}
+	return spans, nil
+}
+
+// cut returns where the chunk starting at start should end, or start if no
+// valid split exists. It prefers line breaks, then sentence ends, then word
+// boundaries, and splits words only when a single word exceeds budget.
+func (s *splitter) cut(start, budget, maxLinks int) int {
+	limit := start + budget
+
+	// End the chunk before a link that would exceed the link limit
+	unique := make(map[string]bool)
+	for i, u := range s.urls {
+		if u.start < start {
+			continue
+		}
+		if u.start >= limit {
+			break
+		}
+		if !unique[s.urlKeys[i]] {
+			if len(unique) == maxLinks {
+				limit = u.start
+				break
+			}
+			unique[s.urlKeys[i]] = true
+		}
+	}
+
+	if s.n <= limit {
+		return s.n
+	}
+
+	lastLine, lastSentence, lastWord, lastAny := 0, 0, 0, 0
+	for b := start + 1; b <= limit; b++ {
+		if !s.bound[b] || s.inURL[b] {
+			continue
+		}
+		lastAny = b
+		switch r := s.runes[b]; {
+		case r == '\n' || r == '\r':
+			lastLine = b
+		case unicode.IsSpace(r):
+			lastWord = b
+			if s.endsSentence(b) {
+				lastSentence = b
+			}
+		case strings.ContainsRune("。！？", s.runes[b-1]):
+			// Full-width punctuation ends sentences without a space
+			lastSentence = b
+		}
+	}
+
+	// Avoid tiny chunks by only using a preferred break in the second half
+	half := start + budget/2
+	switch {
+	case lastLine >= half:
+		return lastLine
+	case lastSentence >= half:
+		return lastSentence
+	case lastWord > start:
+		return lastWord
+	case lastAny > start:
+		return lastAny
+	default:
+		return start
+	}
+}
+
+// endsSentence reports whether the text before position b ends a sentence.
+func (s *splitter) endsSentence(b int) bool {
+	i := b - 1
+	for i > 0 && strings.ContainsRune(`"')]”’»`, s.runes[i]) {
+		i--
+	}
+	return strings.ContainsRune(".!?…。！？", s.runes[i])
+}
+
+// skipSpace returns the first position at or after i that is not whitespace.
+func (s *splitter) skipSpace(i int) int {
+	for i < s.n && unicode.IsSpace(s.runes[i]) {
+		i++
+	}
+	return i
+}
+
+// chunks builds the chunks for spans, moving entities into them.
+func (s *splitter) chunks(spans []span, entities []threads.TextEntity, counter bool) []Chunk {
+	chunks := make([]Chunk, len(spans))
+	for i, sp := range spans {
+		text := string(s.runes[sp.start:sp.end])
+		if counter {
+			text += fmt.Sprintf(" (%d/%d)", i+1, len(spans))
+		}
+		chunks[i].Text = text
+
+		for _, entity := range entities {
+			lo := max(entity.Offset, sp.start)
+			hi := min(entity.Offset+entity.Length, sp.end)
+			if lo < hi {
+				chunks[i].TextEntities = append(chunks[i].TextEntities, threads.TextEntity{
+					EntityType: entity.EntityType,
+					Offset:     lo - sp.start,
+					Length:     hi - lo,
+				})
+			}
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,142 @@
+package textsplit
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+func TestSplit_FitsInOneChunk(t *testing.T) {
+	chunks, err := Split("  Short post.  ", &Options{Counter: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 1 || chunks[0].Text != "Short post." {
+		t.Errorf("expected one trimmed chunk without counter, got %+v", chunks)
+	}
+}
+
+func TestSplit_PrefersSentenceBoundaries(t *testing.T) {
+	text := "The first sentence is here. The second sentence follows it. A third one ends the text."
+
+	chunks, err := Split(text, &Options{MaxLength: 70})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || chunks[0].Text != "The first sentence is here. The second sentence follows it." {
+		t.Fatalf("expected a split after the second sentence, got %q", chunks)
+	}
+	if chunks[1].Text != "A third one ends the text." {
+		t.Errorf("unexpected second chunk %q", chunks[1].Text)
+	}
+}
+
+func TestSplit_CounterAndLimits(t *testing.T) {
+	text := strings.Repeat("word ", 400)
+
+	chunks, err := Split(text, &Options{Counter: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, chunk := range chunks {
+		if n := utf8.RuneCountInString(chunk.Text); n > threads.MaxTextLength {
+			t.Errorf("chunk %d has %d characters", i, n)
+		}
+		if want := " (" + strconv.Itoa(i+1) + "/" + strconv.Itoa(len(chunks)) + ")"; !strings.HasSuffix(chunk.Text, want) {
+			t.Errorf("chunk %d: expected suffix %q, got %q", i, want, chunk.Text)
+		}
+		if strings.Contains(chunk.Text, "wo (") || strings.HasPrefix(chunk.Text, "rd") {
+			t.Errorf("chunk %d splits a word: %q", i, chunk.Text)
+		}
+	}
+}
+
+func TestSplit_NeverSplitsLinksOrEmoji(t *testing.T) {
+	link := "https://example.com/" + strings.Repeat("a", 10)
+	family := "👨‍👩‍👧‍👦"
+	flag := "🇯🇵"
+	text := strings.Repeat(family+flag, 10) + link + " end"
+
+	chunks, err := Split(text, &Options{MaxLength: 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := ""
+	for _, chunk := range chunks {
+		if utf8.RuneCountInString(chunk.Text) > 40 {
+			t.Errorf("chunk too long: %q", chunk.Text)
+		}
+		rest := strings.NewReplacer(family, "", flag, "", link, "").Replace(chunk.Text)
+		if strings.ContainsAny(rest, "\u200d👨👩👧👦🇯🇵") {
+			t.Errorf("chunk splits an emoji sequence: %q", chunk.Text)
+		}
+		joined += chunk.Text
+	}
+	if !strings.Contains(joined, link) {
+		t.Errorf("expected the link to stay whole, got %q", chunks)
+	}
+
+	if _, err := Split(link, &Options{MaxLength: 20}); !threads.IsValidationError(err) {
+		t.Errorf("expected ValidationError for a link longer than a chunk, got %v", err)
+	}
+}
+
+func TestSplit_LinkLimit(t *testing.T) {
+	var links []string
+	for i := 0; i < 7; i++ {
+		links = append(links, "https://example.com/"+strconv.Itoa(i))
+	}
+
+	chunks, err := Split(strings.Join(links, " "), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || strings.Count(chunks[0].Text, "https://") != threads.MaxLinks {
+		t.Errorf("expected the sixth link to start a new chunk, got %q", chunks)
+	}
+}
+
+func TestSplit_RedistributesEntities(t *testing.T) {
+	text := "Intro sentence here. Secret ending revealed now."
+	secret := strings.Index(text, "Secret")
+	chunks, err := Split(text, &Options{
+		MaxLength: 30,
+		TextEntities: []threads.TextEntity{
+			{EntityType: "SPOILER", Offset: secret, Length: len("Secret ending revealed")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || len(chunks[0].TextEntities) != 0 {
+		t.Fatalf("expected the spoiler to move to the second chunk, got %+v", chunks)
+	}
+	entity := chunks[1].TextEntities[0]
+	if got := chunks[1].Text[entity.Offset : entity.Offset+entity.Length]; got != "Secret ending revealed" {
+		t.Errorf("expected the spoiler to cover the same text, got %q", got)
+	}
+
+	drafts := Drafts(chunks)
+	if content, ok := drafts[1].(*threads.TextPostContent); !ok || content.Text != chunks[1].Text || len(content.TextEntities) != 1 {
+		t.Errorf("unexpected draft %+v", drafts[1])
+	}
+}
+
+func TestClusterBounds(t *testing.T) {
+	for text, clusters := range map[string]int{
+		"abc":    3,
+		"éx":    2,
+		"👍🏽!":    2,
+		"🇫🇷🇩🇪":   2,
+		"👩‍💻 ok": 4,
+		"a\r\nb": 3,
+		"\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F": 1,
+	} {
+		if got := len(clusterBounds([]rune(text))) - 1; got != clusters {
+			t.Errorf("%q: expected %d clusters, got %d", text, clusters, got)
+		}
+	}
+}
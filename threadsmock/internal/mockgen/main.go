@@ -0,0 +1,262 @@
+// Command mockgen generates the threadsmock.Client methods from the interface
+// declarations in the threads package. Run it with go generate from the
+// threadsmock directory whenever interfaces.go changes.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+type param struct {
+	name string
+	typ  string
+}
+
+type method struct {
+	iface   string
+	name    string
+	params  []param
+	results []string
+}
+
+func main() {
+	src := flag.String("src", "../interfaces.go", "file declaring the threads interfaces")
+	out := flag.String("out", "mock_gen.go", "output file")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *src, nil, 0)
+	if err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+
+	var ifaces []string
+	var methods []method
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			ifaces = append(ifaces, ts.Name.Name)
+			for _, field := range it.Methods.List {
+				ft, ok := field.Type.(*ast.FuncType)
+				if !ok {
+					continue // embedded interface
+				}
+				methods = append(methods, newMethod(ts.Name.Name, field.Names[0].Name, ft))
+			}
+		}
+	}
+
+	code, err := format.Source(render(ifaces, methods))
+	if err != nil {
+		log.Fatalf("mockgen: formatting output: %v", err)
+	}
+	if err := os.WriteFile(*out, code, 0o644); err != nil {
+		log.Fatalf("mockgen: %v", err)
+	}
+}
+
+func newMethod(iface, name string, ft *ast.FuncType) method {
+	m := method{iface: iface, name: name}
+	for i, field := range ft.Params.List {
+		typ := typeString(field.Type)
+		if len(field.Names) == 0 {
+			m.params = append(m.params, param{name: fmt.Sprintf("arg%d", i), typ: typ})
+			continue
+		}
+		for _, n := range field.Names {
+			m.params = append(m.params, param{name: n.Name, typ: typ})
+		}
+	}
+	if ft.Results != nil {
+		for _, field := range ft.Results.List {
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				m.results = append(m.results, typeString(field.Type))
+			}
+		}
+	}
+	return m
+}
+
+// typeString renders a type expression from package threads so that it can
+// be used from package threadsmock.
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "threads." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.InterfaceType:
+		return "interface{}"
+	case *ast.FuncType:
+		var params, results []string
+		for _, f := range t.Params.List {
+			typ := typeString(f.Type)
+			if len(f.Names) == 0 {
+				params = append(params, typ)
+			}
+			for _, n := range f.Names {
+				params = append(params, n.Name+" "+typ)
+			}
+		}
+		if t.Results != nil {
+			for _, f := range t.Results.List {
+				results = append(results, typeString(f.Type))
+			}
+		}
+		s := "func(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			s += " " + results[0]
+		default:
+			s += " (" + strings.Join(results, ", ") + ")"
+		}
+		return s
+	default:
+		log.Fatalf("mockgen: unsupported type expression %T", expr)
+		return ""
+	}
+}
+
+func (m method) signature() string {
+	params := make([]string, len(m.params))
+	for i, p := range m.params {
+		params[i] = p.name + " " + p.typ
+	}
+	s := "(" + strings.Join(params, ", ") + ")"
+	switch len(m.results) {
+	case 0:
+	case 1:
+		s += " " + m.results[0]
+	default:
+		s += " (" + strings.Join(m.results, ", ") + ")"
+	}
+	return s
+}
+
+// recordedArgs returns the arguments stored in the call log. Contexts are
+// kept on the Call separately so assertions can ignore them.
+func (m method) recordedArgs() (ctx string, args []string) {
+	ctx = "nil"
+	for _, p := range m.params {
+		if p.typ == "context.Context" {
+			ctx = p.name
+			continue
+		}
+		args = append(args, p.name)
+	}
+	return ctx, args
+}
+
+func render(ifaces []string, methods []method) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by mockgen from interfaces.go; DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "package threadsmock")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "import (")
+	fmt.Fprintln(&b, `	"context"`)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, `	threads "github.com/tirthpatell/threads-go"`)
+	fmt.Fprintln(&b, ")")
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "var (")
+	for _, name := range ifaces {
+		fmt.Fprintf(&b, "\t_ threads.%s = (*Client)(nil)\n", name)
+	}
+	fmt.Fprintln(&b, ")")
+	fmt.Fprintln(&b)
+
+	fmt.Fprintln(&b, "// Client is a programmable mock of threads.ClientInterface and each of its")
+	fmt.Fprintln(&b, "// sub-interfaces. Set the XxxFunc field to stub method Xxx; a nil stub makes")
+	fmt.Fprintln(&b, "// the method return zero values and, where it returns an error, an error")
+	fmt.Fprintln(&b, "// wrapping ErrNotStubbed. Every call is recorded regardless of stubbing.")
+	fmt.Fprintln(&b, "//")
+	fmt.Fprintln(&b, "// Stubs must be set before the mock is used concurrently.")
+	fmt.Fprintln(&b, "type Client struct {")
+	fmt.Fprintln(&b, "\trecorder")
+	fmt.Fprintln(&b)
+	iface := ""
+	for _, m := range methods {
+		if m.iface != iface {
+			if iface != "" {
+				fmt.Fprintln(&b)
+			}
+			fmt.Fprintf(&b, "\t// %s\n", m.iface)
+			iface = m.iface
+		}
+		fmt.Fprintf(&b, "\t%sFunc func%s\n", m.name, m.signature())
+	}
+	fmt.Fprintln(&b, "}")
+
+	for _, m := range methods {
+		ctx, args := m.recordedArgs()
+		callArgs := make([]string, len(m.params))
+		for i, p := range m.params {
+			callArgs[i] = p.name
+			if strings.HasPrefix(p.typ, "...") {
+				callArgs[i] += "..."
+			}
+		}
+
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "// %s implements threads.%s.\n", m.name, m.iface)
+		fmt.Fprintf(&b, "func (m *Client) %s%s {\n", m.name, m.signature())
+		fmt.Fprintf(&b, "\tm.record(%q, %s, []interface{}{%s})\n", m.name, ctx, strings.Join(args, ", "))
+		fmt.Fprintf(&b, "\tif m.%sFunc != nil {\n", m.name)
+		if len(m.results) == 0 {
+			fmt.Fprintf(&b, "\t\tm.%sFunc(%s)\n\t\treturn\n", m.name, strings.Join(callArgs, ", "))
+		} else {
+			fmt.Fprintf(&b, "\t\treturn m.%sFunc(%s)\n", m.name, strings.Join(callArgs, ", "))
+		}
+		fmt.Fprintln(&b, "\t}")
+
+		rets := make([]string, len(m.results))
+		for i, r := range m.results {
+			if r == "error" {
+				rets[i] = fmt.Sprintf("m.notStubbed(%q)", m.name)
+				continue
+			}
+			rets[i] = fmt.Sprintf("r%d", i)
+			fmt.Fprintf(&b, "\tvar r%d %s\n", i, r)
+		}
+		if len(rets) > 0 {
+			fmt.Fprintf(&b, "\treturn %s\n", strings.Join(rets, ", "))
+		}
+		fmt.Fprintln(&b, "}")
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,184 @@
+// Package threadsmock provides a programmable mock of threads.ClientInterface
+// for unit tests.
+//
+// Client satisfies ClientInterface and every sub-interface it is composed of
+// (PostCreator, PostReader, ReplyManager, ...), so it can stand in for
+// whichever narrow interface your code depends on. Stub a method by setting
+// its Func field, then assert on the recorded calls:
+//
+//	mock := &threadsmock.Client{
+//		CreateTextPostFunc: func(ctx context.Context, content *threads.TextPostContent) (*threads.Post, error) {
+//			return &threads.Post{ID: "1", Text: content.Text}, nil
+//		},
+//	}
+//
+//	publishGreeting(ctx, mock)
+//
+//	mock.AssertCalledWith(t, "CreateTextPost", &threads.TextPostContent{Text: "hello"})
+//
+// The methods are generated from interfaces.go; run go generate in this
+// directory after changing the interfaces.
+package threadsmock
+
+//go:generate go run ./internal/mockgen -src ../interfaces.go -out mock_gen.go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrNotStubbed is wrapped by the error returned from any method whose Func
+// field has not been set.
+var ErrNotStubbed = errors.New("threadsmock: method not stubbed")
+
+// Any matches any argument value in AssertCalledWith.
+var Any = anyArg{}
+
+type anyArg struct{}
+
+// Call is a record of one method invocation. Context arguments are stored in
+// Ctx rather than Args so that argument assertions can ignore them.
+type Call struct {
+	Method string
+	Ctx    context.Context
+	Args   []interface{}
+}
+
+// TestingT is the subset of testing.TB used by the assertion helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// recorder keeps the call log shared by every mocked method.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, ctx context.Context, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Ctx: ctx, Args: args})
+}
+
+func (r *recorder) notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// Calls returns the recorded calls to method in invocation order. An empty
+// method returns every recorded call.
+func (r *recorder) Calls(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []Call
+	for _, c := range r.calls {
+		if method == "" || c.Method == method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// CallCount returns how many times method was called.
+func (r *recorder) CallCount(method string) int {
+	return len(r.Calls(method))
+}
+
+// Reset clears the call log. Stubs are left in place.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled reports a test error unless method was called at least once.
+func (r *recorder) AssertCalled(t TestingT, method string) bool {
+	t.Helper()
+	if r.CallCount(method) == 0 {
+		t.Errorf("threadsmock: expected %s to be called, but it was not", method)
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled reports a test error if method was called.
+func (r *recorder) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+	if n := r.CallCount(method); n > 0 {
+		t.Errorf("threadsmock: expected %s not to be called, but it was called %d time(s)", method, n)
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls reports a test error unless method was called exactly n times.
+func (r *recorder) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if got := r.CallCount(method); got != n {
+		t.Errorf("threadsmock: expected %s to be called %d time(s), got %d", method, n, got)
+		return false
+	}
+	return true
+}
+
+// AssertCalledWith reports a test error unless at least one call to method
+// had arguments deeply equal to args. Context arguments are excluded; use Any
+// to match an argument position with any value.
+func (r *recorder) AssertCalledWith(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	calls := r.Calls(method)
+	for _, c := range calls {
+		if argsMatch(c.Args, args) {
+			return true
+		}
+	}
+
+	if len(calls) == 0 {
+		t.Errorf("threadsmock: expected %s to be called with %s, but it was not called", method, formatArgs(args))
+		return false
+	}
+	seen := make([]string, len(calls))
+	for i, c := range calls {
+		seen[i] = formatArgs(c.Args)
+	}
+	t.Errorf("threadsmock: expected %s to be called with %s, got calls:\n\t%s",
+		method, formatArgs(args), strings.Join(seen, "\n\t"))
+	return false
+}
+
+func argsMatch(got, want []interface{}) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if _, ok := want[i].(anyArg); ok {
+			continue
+		}
+		if !reflect.DeepEqual(got[i], want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatArgs(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		if _, ok := a.(anyArg); ok {
+			parts[i] = "<any>"
+			continue
+		}
+		v := reflect.ValueOf(a)
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			parts[i] = fmt.Sprintf("&%+v", v.Elem().Interface())
+			continue
+		}
+		parts[i] = fmt.Sprintf("%+v", a)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
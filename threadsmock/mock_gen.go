@@ -0,0 +1,808 @@
+// Code generated by mockgen from interfaces.go; DO NOT EDIT.
+
+package threadsmock
+
+import (
+	"context"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+var (
+	_ threads.ClientInterface     = (*Client)(nil)
+	_ threads.Authenticator       = (*Client)(nil)
+	_ threads.PostManager         = (*Client)(nil)
+	_ threads.PostCreator         = (*Client)(nil)
+	_ threads.PostReader          = (*Client)(nil)
+	_ threads.PostDeleter         = (*Client)(nil)
+	_ threads.PostValidator       = (*Client)(nil)
+	_ threads.UserManager         = (*Client)(nil)
+	_ threads.ReplyManager        = (*Client)(nil)
+	_ threads.InsightsProvider    = (*Client)(nil)
+	_ threads.LocationManager     = (*Client)(nil)
+	_ threads.SearchProvider      = (*Client)(nil)
+	_ threads.RateLimitController = (*Client)(nil)
+)
+
+// Client is a programmable mock of threads.ClientInterface and each of its
+// sub-interfaces. Set the XxxFunc field to stub method Xxx; a nil stub makes
+// the method return zero values and, where it returns an error, an error
+// wrapping ErrNotStubbed. Every call is recorded regardless of stubbing.
+//
+// Stubs must be set before the mock is used concurrently.
+type Client struct {
+	recorder
+
+	// Authenticator
+	GetAuthURLFunc                 func(scopes []string) (string, string, error)
+	ExchangeCodeForTokenFunc       func(ctx context.Context, code string, expectedState string, receivedState string) error
+	GetLongLivedTokenFunc          func(ctx context.Context) error
+	RefreshTokenFunc               func(ctx context.Context) error
+	DebugTokenFunc                 func(ctx context.Context, inputToken string) (*threads.DebugTokenResponse, error)
+	SetTokenFromDebugInfoFunc      func(accessToken string, debugResp *threads.DebugTokenResponse) error
+	GetTokenDebugInfoFunc          func() map[string]interface{}
+	GetAppAccessTokenFunc          func(ctx context.Context) (*threads.AppAccessTokenResponse, error)
+	GetAppAccessTokenShorthandFunc func() string
+
+	// PostCreator
+	CreateTextPostFunc       func(ctx context.Context, content *threads.TextPostContent) (*threads.Post, error)
+	CreateImagePostFunc      func(ctx context.Context, content *threads.ImagePostContent) (*threads.Post, error)
+	CreateVideoPostFunc      func(ctx context.Context, content *threads.VideoPostContent) (*threads.Post, error)
+	CreateCarouselPostFunc   func(ctx context.Context, content *threads.CarouselPostContent) (*threads.Post, error)
+	CreateThreadChainFunc    func(ctx context.Context, parts []threads.PostDraft, opts *threads.ThreadChainOptions) (*threads.ThreadChainResult, error)
+	ResumeThreadChainFunc    func(ctx context.Context, result *threads.ThreadChainResult, parts []threads.PostDraft, opts *threads.ThreadChainOptions) (*threads.ThreadChainResult, error)
+	RollbackThreadChainFunc  func(ctx context.Context, result *threads.ThreadChainResult) error
+	CreateQuotePostFunc      func(ctx context.Context, content interface{}, quotedPostID string) (*threads.Post, error)
+	RepostPostFunc           func(ctx context.Context, postID threads.PostID) (*threads.Post, error)
+	CreateMediaContainerFunc func(ctx context.Context, mediaType string, mediaURL string, altText string) (threads.ContainerID, error)
+	GetContainerStatusFunc   func(ctx context.Context, containerID threads.ContainerID) (*threads.ContainerStatus, error)
+	PrepareTextPostFunc      func(ctx context.Context, content *threads.TextPostContent) (threads.ContainerID, error)
+	PrepareImagePostFunc     func(ctx context.Context, content *threads.ImagePostContent) (threads.ContainerID, error)
+	PrepareVideoPostFunc     func(ctx context.Context, content *threads.VideoPostContent) (threads.ContainerID, error)
+	PrepareCarouselPostFunc  func(ctx context.Context, content *threads.CarouselPostContent) (threads.ContainerID, error)
+	WaitForContainerFunc     func(ctx context.Context, containerID threads.ContainerID) error
+	PublishContainerFunc     func(ctx context.Context, containerID threads.ContainerID) (*threads.Post, error)
+
+	// PostReader
+	GetPostFunc                 func(ctx context.Context, postID threads.PostID) (*threads.Post, error)
+	GetUserPostsFunc            func(ctx context.Context, userID threads.UserID, opts *threads.PaginationOptions) (*threads.PostsResponse, error)
+	GetUserPostsWithOptionsFunc func(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.PostsResponse, error)
+	GetUserMentionsFunc         func(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.PostsResponse, error)
+	GetUserGhostPostsFunc       func(ctx context.Context, userID threads.UserID, opts *threads.PaginationOptions) (*threads.PostsResponse, error)
+	GetPublishingLimitsFunc     func(ctx context.Context) (*threads.PublishingLimits, error)
+
+	// PostDeleter
+	DeletePostFunc                 func(ctx context.Context, postID threads.PostID) (string, error)
+	DeletePostWithConfirmationFunc func(ctx context.Context, postID threads.PostID, confirmationCallback func(post *threads.Post) bool) (string, error)
+
+	// PostValidator
+	ValidateTextPostContentFunc     func(content *threads.TextPostContent) error
+	ValidateImagePostContentFunc    func(content *threads.ImagePostContent) error
+	ValidateVideoPostContentFunc    func(content *threads.VideoPostContent) error
+	ValidateCarouselPostContentFunc func(content *threads.CarouselPostContent) error
+	ValidateCarouselChildrenFunc    func(childrenIDs []string) error
+	ValidateTopicTagFunc            func(tag string) error
+	ValidateCountryCodesFunc        func(codes []string) error
+
+	// UserManager
+	GetUserFunc               func(ctx context.Context, userID threads.UserID) (*threads.User, error)
+	GetMeFunc                 func(ctx context.Context) (*threads.User, error)
+	GetUserFieldsFunc         func(ctx context.Context, userID threads.UserID, fields []string) (*threads.User, error)
+	LookupPublicProfileFunc   func(ctx context.Context, username string) (*threads.PublicUser, error)
+	GetPublicProfilePostsFunc func(ctx context.Context, username string, opts *threads.PostsOptions) (*threads.PostsResponse, error)
+
+	// ReplyManager
+	CreateReplyFunc         func(ctx context.Context, content *threads.PostContent) (*threads.Post, error)
+	ReplyToPostFunc         func(ctx context.Context, postID threads.PostID, content *threads.PostContent) (*threads.Post, error)
+	ReplyWithDraftFunc      func(ctx context.Context, draft threads.PostDraft) (*threads.Post, error)
+	GetRepliesFunc          func(ctx context.Context, postID threads.PostID, opts *threads.RepliesOptions) (*threads.RepliesResponse, error)
+	GetConversationFunc     func(ctx context.Context, postID threads.PostID, opts *threads.RepliesOptions) (*threads.RepliesResponse, error)
+	HideReplyFunc           func(ctx context.Context, replyID threads.PostID) error
+	UnhideReplyFunc         func(ctx context.Context, replyID threads.PostID) error
+	GetUserRepliesFunc      func(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.RepliesResponse, error)
+	GetPendingRepliesFunc   func(ctx context.Context, postID threads.PostID, opts *threads.PendingRepliesOptions) (*threads.RepliesResponse, error)
+	ApprovePendingReplyFunc func(ctx context.Context, replyID threads.PostID) error
+	IgnorePendingReplyFunc  func(ctx context.Context, replyID threads.PostID) error
+
+	// InsightsProvider
+	GetPostInsightsFunc               func(ctx context.Context, postID threads.PostID, metrics []string) (*threads.InsightsResponse, error)
+	GetPostInsightsWithOptionsFunc    func(ctx context.Context, postID threads.PostID, opts *threads.PostInsightsOptions) (*threads.InsightsResponse, error)
+	GetAccountInsightsFunc            func(ctx context.Context, userID threads.UserID, metrics []string, period string) (*threads.InsightsResponse, error)
+	GetAccountInsightsWithOptionsFunc func(ctx context.Context, userID threads.UserID, opts *threads.AccountInsightsOptions) (*threads.InsightsResponse, error)
+
+	// LocationManager
+	SearchLocationsFunc func(ctx context.Context, query string, latitude *float64, longitude *float64) (*threads.LocationSearchResponse, error)
+	GetLocationFunc     func(ctx context.Context, locationID threads.LocationID) (*threads.Location, error)
+
+	// SearchProvider
+	KeywordSearchFunc func(ctx context.Context, query string, opts *threads.SearchOptions) (*threads.PostsResponse, error)
+
+	// RateLimitController
+	IsRateLimitedFunc       func() bool
+	DisableRateLimitingFunc func()
+	EnableRateLimitingFunc  func()
+	GetRateLimitStatusFunc  func() threads.RateLimitStatus
+	IsNearRateLimitFunc     func(threshold float64) bool
+	WaitForRateLimitFunc    func(ctx context.Context) error
+}
+
+// GetAuthURL implements threads.Authenticator.
+func (m *Client) GetAuthURL(scopes []string) (string, string, error) {
+	m.record("GetAuthURL", nil, []interface{}{scopes})
+	if m.GetAuthURLFunc != nil {
+		return m.GetAuthURLFunc(scopes)
+	}
+	var r0 string
+	var r1 string
+	return r0, r1, m.notStubbed("GetAuthURL")
+}
+
+// ExchangeCodeForToken implements threads.Authenticator.
+func (m *Client) ExchangeCodeForToken(ctx context.Context, code string, expectedState string, receivedState string) error {
+	m.record("ExchangeCodeForToken", ctx, []interface{}{code, expectedState, receivedState})
+	if m.ExchangeCodeForTokenFunc != nil {
+		return m.ExchangeCodeForTokenFunc(ctx, code, expectedState, receivedState)
+	}
+	return m.notStubbed("ExchangeCodeForToken")
+}
+
+// GetLongLivedToken implements threads.Authenticator.
+func (m *Client) GetLongLivedToken(ctx context.Context) error {
+	m.record("GetLongLivedToken", ctx, []interface{}{})
+	if m.GetLongLivedTokenFunc != nil {
+		return m.GetLongLivedTokenFunc(ctx)
+	}
+	return m.notStubbed("GetLongLivedToken")
+}
+
+// RefreshToken implements threads.Authenticator.
+func (m *Client) RefreshToken(ctx context.Context) error {
+	m.record("RefreshToken", ctx, []interface{}{})
+	if m.RefreshTokenFunc != nil {
+		return m.RefreshTokenFunc(ctx)
+	}
+	return m.notStubbed("RefreshToken")
+}
+
+// DebugToken implements threads.Authenticator.
+func (m *Client) DebugToken(ctx context.Context, inputToken string) (*threads.DebugTokenResponse, error) {
+	m.record("DebugToken", ctx, []interface{}{inputToken})
+	if m.DebugTokenFunc != nil {
+		return m.DebugTokenFunc(ctx, inputToken)
+	}
+	var r0 *threads.DebugTokenResponse
+	return r0, m.notStubbed("DebugToken")
+}
+
+// SetTokenFromDebugInfo implements threads.Authenticator.
+func (m *Client) SetTokenFromDebugInfo(accessToken string, debugResp *threads.DebugTokenResponse) error {
+	m.record("SetTokenFromDebugInfo", nil, []interface{}{accessToken, debugResp})
+	if m.SetTokenFromDebugInfoFunc != nil {
+		return m.SetTokenFromDebugInfoFunc(accessToken, debugResp)
+	}
+	return m.notStubbed("SetTokenFromDebugInfo")
+}
+
+// GetTokenDebugInfo implements threads.Authenticator.
+func (m *Client) GetTokenDebugInfo() map[string]interface{} {
+	m.record("GetTokenDebugInfo", nil, []interface{}{})
+	if m.GetTokenDebugInfoFunc != nil {
+		return m.GetTokenDebugInfoFunc()
+	}
+	var r0 map[string]interface{}
+	return r0
+}
+
+// GetAppAccessToken implements threads.Authenticator.
+func (m *Client) GetAppAccessToken(ctx context.Context) (*threads.AppAccessTokenResponse, error) {
+	m.record("GetAppAccessToken", ctx, []interface{}{})
+	if m.GetAppAccessTokenFunc != nil {
+		return m.GetAppAccessTokenFunc(ctx)
+	}
+	var r0 *threads.AppAccessTokenResponse
+	return r0, m.notStubbed("GetAppAccessToken")
+}
+
+// GetAppAccessTokenShorthand implements threads.Authenticator.
+func (m *Client) GetAppAccessTokenShorthand() string {
+	m.record("GetAppAccessTokenShorthand", nil, []interface{}{})
+	if m.GetAppAccessTokenShorthandFunc != nil {
+		return m.GetAppAccessTokenShorthandFunc()
+	}
+	var r0 string
+	return r0
+}
+
+// CreateTextPost implements threads.PostCreator.
+func (m *Client) CreateTextPost(ctx context.Context, content *threads.TextPostContent) (*threads.Post, error) {
+	m.record("CreateTextPost", ctx, []interface{}{content})
+	if m.CreateTextPostFunc != nil {
+		return m.CreateTextPostFunc(ctx, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateTextPost")
+}
+
+// CreateImagePost implements threads.PostCreator.
+func (m *Client) CreateImagePost(ctx context.Context, content *threads.ImagePostContent) (*threads.Post, error) {
+	m.record("CreateImagePost", ctx, []interface{}{content})
+	if m.CreateImagePostFunc != nil {
+		return m.CreateImagePostFunc(ctx, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateImagePost")
+}
+
+// CreateVideoPost implements threads.PostCreator.
+func (m *Client) CreateVideoPost(ctx context.Context, content *threads.VideoPostContent) (*threads.Post, error) {
+	m.record("CreateVideoPost", ctx, []interface{}{content})
+	if m.CreateVideoPostFunc != nil {
+		return m.CreateVideoPostFunc(ctx, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateVideoPost")
+}
+
+// CreateCarouselPost implements threads.PostCreator.
+func (m *Client) CreateCarouselPost(ctx context.Context, content *threads.CarouselPostContent) (*threads.Post, error) {
+	m.record("CreateCarouselPost", ctx, []interface{}{content})
+	if m.CreateCarouselPostFunc != nil {
+		return m.CreateCarouselPostFunc(ctx, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateCarouselPost")
+}
+
+// CreateThreadChain implements threads.PostCreator.
+func (m *Client) CreateThreadChain(ctx context.Context, parts []threads.PostDraft, opts *threads.ThreadChainOptions) (*threads.ThreadChainResult, error) {
+	m.record("CreateThreadChain", ctx, []interface{}{parts, opts})
+	if m.CreateThreadChainFunc != nil {
+		return m.CreateThreadChainFunc(ctx, parts, opts)
+	}
+	var r0 *threads.ThreadChainResult
+	return r0, m.notStubbed("CreateThreadChain")
+}
+
+// ResumeThreadChain implements threads.PostCreator.
+func (m *Client) ResumeThreadChain(ctx context.Context, result *threads.ThreadChainResult, parts []threads.PostDraft, opts *threads.ThreadChainOptions) (*threads.ThreadChainResult, error) {
+	m.record("ResumeThreadChain", ctx, []interface{}{result, parts, opts})
+	if m.ResumeThreadChainFunc != nil {
+		return m.ResumeThreadChainFunc(ctx, result, parts, opts)
+	}
+	var r0 *threads.ThreadChainResult
+	return r0, m.notStubbed("ResumeThreadChain")
+}
+
+// RollbackThreadChain implements threads.PostCreator.
+func (m *Client) RollbackThreadChain(ctx context.Context, result *threads.ThreadChainResult) error {
+	m.record("RollbackThreadChain", ctx, []interface{}{result})
+	if m.RollbackThreadChainFunc != nil {
+		return m.RollbackThreadChainFunc(ctx, result)
+	}
+	return m.notStubbed("RollbackThreadChain")
+}
+
+// CreateQuotePost implements threads.PostCreator.
+func (m *Client) CreateQuotePost(ctx context.Context, content interface{}, quotedPostID string) (*threads.Post, error) {
+	m.record("CreateQuotePost", ctx, []interface{}{content, quotedPostID})
+	if m.CreateQuotePostFunc != nil {
+		return m.CreateQuotePostFunc(ctx, content, quotedPostID)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateQuotePost")
+}
+
+// RepostPost implements threads.PostCreator.
+func (m *Client) RepostPost(ctx context.Context, postID threads.PostID) (*threads.Post, error) {
+	m.record("RepostPost", ctx, []interface{}{postID})
+	if m.RepostPostFunc != nil {
+		return m.RepostPostFunc(ctx, postID)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("RepostPost")
+}
+
+// CreateMediaContainer implements threads.PostCreator.
+func (m *Client) CreateMediaContainer(ctx context.Context, mediaType string, mediaURL string, altText string) (threads.ContainerID, error) {
+	m.record("CreateMediaContainer", ctx, []interface{}{mediaType, mediaURL, altText})
+	if m.CreateMediaContainerFunc != nil {
+		return m.CreateMediaContainerFunc(ctx, mediaType, mediaURL, altText)
+	}
+	var r0 threads.ContainerID
+	return r0, m.notStubbed("CreateMediaContainer")
+}
+
+// GetContainerStatus implements threads.PostCreator.
+func (m *Client) GetContainerStatus(ctx context.Context, containerID threads.ContainerID) (*threads.ContainerStatus, error) {
+	m.record("GetContainerStatus", ctx, []interface{}{containerID})
+	if m.GetContainerStatusFunc != nil {
+		return m.GetContainerStatusFunc(ctx, containerID)
+	}
+	var r0 *threads.ContainerStatus
+	return r0, m.notStubbed("GetContainerStatus")
+}
+
+// PrepareTextPost implements threads.PostCreator.
+func (m *Client) PrepareTextPost(ctx context.Context, content *threads.TextPostContent) (threads.ContainerID, error) {
+	m.record("PrepareTextPost", ctx, []interface{}{content})
+	if m.PrepareTextPostFunc != nil {
+		return m.PrepareTextPostFunc(ctx, content)
+	}
+	var r0 threads.ContainerID
+	return r0, m.notStubbed("PrepareTextPost")
+}
+
+// PrepareImagePost implements threads.PostCreator.
+func (m *Client) PrepareImagePost(ctx context.Context, content *threads.ImagePostContent) (threads.ContainerID, error) {
+	m.record("PrepareImagePost", ctx, []interface{}{content})
+	if m.PrepareImagePostFunc != nil {
+		return m.PrepareImagePostFunc(ctx, content)
+	}
+	var r0 threads.ContainerID
+	return r0, m.notStubbed("PrepareImagePost")
+}
+
+// PrepareVideoPost implements threads.PostCreator.
+func (m *Client) PrepareVideoPost(ctx context.Context, content *threads.VideoPostContent) (threads.ContainerID, error) {
+	m.record("PrepareVideoPost", ctx, []interface{}{content})
+	if m.PrepareVideoPostFunc != nil {
+		return m.PrepareVideoPostFunc(ctx, content)
+	}
+	var r0 threads.ContainerID
+	return r0, m.notStubbed("PrepareVideoPost")
+}
+
+// PrepareCarouselPost implements threads.PostCreator.
+func (m *Client) PrepareCarouselPost(ctx context.Context, content *threads.CarouselPostContent) (threads.ContainerID, error) {
+	m.record("PrepareCarouselPost", ctx, []interface{}{content})
+	if m.PrepareCarouselPostFunc != nil {
+		return m.PrepareCarouselPostFunc(ctx, content)
+	}
+	var r0 threads.ContainerID
+	return r0, m.notStubbed("PrepareCarouselPost")
+}
+
+// WaitForContainer implements threads.PostCreator.
+func (m *Client) WaitForContainer(ctx context.Context, containerID threads.ContainerID) error {
+	m.record("WaitForContainer", ctx, []interface{}{containerID})
+	if m.WaitForContainerFunc != nil {
+		return m.WaitForContainerFunc(ctx, containerID)
+	}
+	return m.notStubbed("WaitForContainer")
+}
+
+// PublishContainer implements threads.PostCreator.
+func (m *Client) PublishContainer(ctx context.Context, containerID threads.ContainerID) (*threads.Post, error) {
+	m.record("PublishContainer", ctx, []interface{}{containerID})
+	if m.PublishContainerFunc != nil {
+		return m.PublishContainerFunc(ctx, containerID)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("PublishContainer")
+}
+
+// GetPost implements threads.PostReader.
+func (m *Client) GetPost(ctx context.Context, postID threads.PostID) (*threads.Post, error) {
+	m.record("GetPost", ctx, []interface{}{postID})
+	if m.GetPostFunc != nil {
+		return m.GetPostFunc(ctx, postID)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("GetPost")
+}
+
+// GetUserPosts implements threads.PostReader.
+func (m *Client) GetUserPosts(ctx context.Context, userID threads.UserID, opts *threads.PaginationOptions) (*threads.PostsResponse, error) {
+	m.record("GetUserPosts", ctx, []interface{}{userID, opts})
+	if m.GetUserPostsFunc != nil {
+		return m.GetUserPostsFunc(ctx, userID, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("GetUserPosts")
+}
+
+// GetUserPostsWithOptions implements threads.PostReader.
+func (m *Client) GetUserPostsWithOptions(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.PostsResponse, error) {
+	m.record("GetUserPostsWithOptions", ctx, []interface{}{userID, opts})
+	if m.GetUserPostsWithOptionsFunc != nil {
+		return m.GetUserPostsWithOptionsFunc(ctx, userID, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("GetUserPostsWithOptions")
+}
+
+// GetUserMentions implements threads.PostReader.
+func (m *Client) GetUserMentions(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.PostsResponse, error) {
+	m.record("GetUserMentions", ctx, []interface{}{userID, opts})
+	if m.GetUserMentionsFunc != nil {
+		return m.GetUserMentionsFunc(ctx, userID, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("GetUserMentions")
+}
+
+// GetUserGhostPosts implements threads.PostReader.
+func (m *Client) GetUserGhostPosts(ctx context.Context, userID threads.UserID, opts *threads.PaginationOptions) (*threads.PostsResponse, error) {
+	m.record("GetUserGhostPosts", ctx, []interface{}{userID, opts})
+	if m.GetUserGhostPostsFunc != nil {
+		return m.GetUserGhostPostsFunc(ctx, userID, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("GetUserGhostPosts")
+}
+
+// GetPublishingLimits implements threads.PostReader.
+func (m *Client) GetPublishingLimits(ctx context.Context) (*threads.PublishingLimits, error) {
+	m.record("GetPublishingLimits", ctx, []interface{}{})
+	if m.GetPublishingLimitsFunc != nil {
+		return m.GetPublishingLimitsFunc(ctx)
+	}
+	var r0 *threads.PublishingLimits
+	return r0, m.notStubbed("GetPublishingLimits")
+}
+
+// DeletePost implements threads.PostDeleter.
+func (m *Client) DeletePost(ctx context.Context, postID threads.PostID) (string, error) {
+	m.record("DeletePost", ctx, []interface{}{postID})
+	if m.DeletePostFunc != nil {
+		return m.DeletePostFunc(ctx, postID)
+	}
+	var r0 string
+	return r0, m.notStubbed("DeletePost")
+}
+
+// DeletePostWithConfirmation implements threads.PostDeleter.
+func (m *Client) DeletePostWithConfirmation(ctx context.Context, postID threads.PostID, confirmationCallback func(post *threads.Post) bool) (string, error) {
+	m.record("DeletePostWithConfirmation", ctx, []interface{}{postID, confirmationCallback})
+	if m.DeletePostWithConfirmationFunc != nil {
+		return m.DeletePostWithConfirmationFunc(ctx, postID, confirmationCallback)
+	}
+	var r0 string
+	return r0, m.notStubbed("DeletePostWithConfirmation")
+}
+
+// ValidateTextPostContent implements threads.PostValidator.
+func (m *Client) ValidateTextPostContent(content *threads.TextPostContent) error {
+	m.record("ValidateTextPostContent", nil, []interface{}{content})
+	if m.ValidateTextPostContentFunc != nil {
+		return m.ValidateTextPostContentFunc(content)
+	}
+	return m.notStubbed("ValidateTextPostContent")
+}
+
+// ValidateImagePostContent implements threads.PostValidator.
+func (m *Client) ValidateImagePostContent(content *threads.ImagePostContent) error {
+	m.record("ValidateImagePostContent", nil, []interface{}{content})
+	if m.ValidateImagePostContentFunc != nil {
+		return m.ValidateImagePostContentFunc(content)
+	}
+	return m.notStubbed("ValidateImagePostContent")
+}
+
+// ValidateVideoPostContent implements threads.PostValidator.
+func (m *Client) ValidateVideoPostContent(content *threads.VideoPostContent) error {
+	m.record("ValidateVideoPostContent", nil, []interface{}{content})
+	if m.ValidateVideoPostContentFunc != nil {
+		return m.ValidateVideoPostContentFunc(content)
+	}
+	return m.notStubbed("ValidateVideoPostContent")
+}
+
+// ValidateCarouselPostContent implements threads.PostValidator.
+func (m *Client) ValidateCarouselPostContent(content *threads.CarouselPostContent) error {
+	m.record("ValidateCarouselPostContent", nil, []interface{}{content})
+	if m.ValidateCarouselPostContentFunc != nil {
+		return m.ValidateCarouselPostContentFunc(content)
+	}
+	return m.notStubbed("ValidateCarouselPostContent")
+}
+
+// ValidateCarouselChildren implements threads.PostValidator.
+func (m *Client) ValidateCarouselChildren(childrenIDs []string) error {
+	m.record("ValidateCarouselChildren", nil, []interface{}{childrenIDs})
+	if m.ValidateCarouselChildrenFunc != nil {
+		return m.ValidateCarouselChildrenFunc(childrenIDs)
+	}
+	return m.notStubbed("ValidateCarouselChildren")
+}
+
+// ValidateTopicTag implements threads.PostValidator.
+func (m *Client) ValidateTopicTag(tag string) error {
+	m.record("ValidateTopicTag", nil, []interface{}{tag})
+	if m.ValidateTopicTagFunc != nil {
+		return m.ValidateTopicTagFunc(tag)
+	}
+	return m.notStubbed("ValidateTopicTag")
+}
+
+// ValidateCountryCodes implements threads.PostValidator.
+func (m *Client) ValidateCountryCodes(codes []string) error {
+	m.record("ValidateCountryCodes", nil, []interface{}{codes})
+	if m.ValidateCountryCodesFunc != nil {
+		return m.ValidateCountryCodesFunc(codes)
+	}
+	return m.notStubbed("ValidateCountryCodes")
+}
+
+// GetUser implements threads.UserManager.
+func (m *Client) GetUser(ctx context.Context, userID threads.UserID) (*threads.User, error) {
+	m.record("GetUser", ctx, []interface{}{userID})
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(ctx, userID)
+	}
+	var r0 *threads.User
+	return r0, m.notStubbed("GetUser")
+}
+
+// GetMe implements threads.UserManager.
+func (m *Client) GetMe(ctx context.Context) (*threads.User, error) {
+	m.record("GetMe", ctx, []interface{}{})
+	if m.GetMeFunc != nil {
+		return m.GetMeFunc(ctx)
+	}
+	var r0 *threads.User
+	return r0, m.notStubbed("GetMe")
+}
+
+// GetUserFields implements threads.UserManager.
+func (m *Client) GetUserFields(ctx context.Context, userID threads.UserID, fields []string) (*threads.User, error) {
+	m.record("GetUserFields", ctx, []interface{}{userID, fields})
+	if m.GetUserFieldsFunc != nil {
+		return m.GetUserFieldsFunc(ctx, userID, fields)
+	}
+	var r0 *threads.User
+	return r0, m.notStubbed("GetUserFields")
+}
+
+// LookupPublicProfile implements threads.UserManager.
+func (m *Client) LookupPublicProfile(ctx context.Context, username string) (*threads.PublicUser, error) {
+	m.record("LookupPublicProfile", ctx, []interface{}{username})
+	if m.LookupPublicProfileFunc != nil {
+		return m.LookupPublicProfileFunc(ctx, username)
+	}
+	var r0 *threads.PublicUser
+	return r0, m.notStubbed("LookupPublicProfile")
+}
+
+// GetPublicProfilePosts implements threads.UserManager.
+func (m *Client) GetPublicProfilePosts(ctx context.Context, username string, opts *threads.PostsOptions) (*threads.PostsResponse, error) {
+	m.record("GetPublicProfilePosts", ctx, []interface{}{username, opts})
+	if m.GetPublicProfilePostsFunc != nil {
+		return m.GetPublicProfilePostsFunc(ctx, username, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("GetPublicProfilePosts")
+}
+
+// CreateReply implements threads.ReplyManager.
+func (m *Client) CreateReply(ctx context.Context, content *threads.PostContent) (*threads.Post, error) {
+	m.record("CreateReply", ctx, []interface{}{content})
+	if m.CreateReplyFunc != nil {
+		return m.CreateReplyFunc(ctx, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("CreateReply")
+}
+
+// ReplyToPost implements threads.ReplyManager.
+func (m *Client) ReplyToPost(ctx context.Context, postID threads.PostID, content *threads.PostContent) (*threads.Post, error) {
+	m.record("ReplyToPost", ctx, []interface{}{postID, content})
+	if m.ReplyToPostFunc != nil {
+		return m.ReplyToPostFunc(ctx, postID, content)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("ReplyToPost")
+}
+
+// ReplyWithDraft implements threads.ReplyManager.
+func (m *Client) ReplyWithDraft(ctx context.Context, draft threads.PostDraft) (*threads.Post, error) {
+	m.record("ReplyWithDraft", ctx, []interface{}{draft})
+	if m.ReplyWithDraftFunc != nil {
+		return m.ReplyWithDraftFunc(ctx, draft)
+	}
+	var r0 *threads.Post
+	return r0, m.notStubbed("ReplyWithDraft")
+}
+
+// GetReplies implements threads.ReplyManager.
+func (m *Client) GetReplies(ctx context.Context, postID threads.PostID, opts *threads.RepliesOptions) (*threads.RepliesResponse, error) {
+	m.record("GetReplies", ctx, []interface{}{postID, opts})
+	if m.GetRepliesFunc != nil {
+		return m.GetRepliesFunc(ctx, postID, opts)
+	}
+	var r0 *threads.RepliesResponse
+	return r0, m.notStubbed("GetReplies")
+}
+
+// GetConversation implements threads.ReplyManager.
+func (m *Client) GetConversation(ctx context.Context, postID threads.PostID, opts *threads.RepliesOptions) (*threads.RepliesResponse, error) {
+	m.record("GetConversation", ctx, []interface{}{postID, opts})
+	if m.GetConversationFunc != nil {
+		return m.GetConversationFunc(ctx, postID, opts)
+	}
+	var r0 *threads.RepliesResponse
+	return r0, m.notStubbed("GetConversation")
+}
+
+// HideReply implements threads.ReplyManager.
+func (m *Client) HideReply(ctx context.Context, replyID threads.PostID) error {
+	m.record("HideReply", ctx, []interface{}{replyID})
+	if m.HideReplyFunc != nil {
+		return m.HideReplyFunc(ctx, replyID)
+	}
+	return m.notStubbed("HideReply")
+}
+
+// UnhideReply implements threads.ReplyManager.
+func (m *Client) UnhideReply(ctx context.Context, replyID threads.PostID) error {
+	m.record("UnhideReply", ctx, []interface{}{replyID})
+	if m.UnhideReplyFunc != nil {
+		return m.UnhideReplyFunc(ctx, replyID)
+	}
+	return m.notStubbed("UnhideReply")
+}
+
+// GetUserReplies implements threads.ReplyManager.
+func (m *Client) GetUserReplies(ctx context.Context, userID threads.UserID, opts *threads.PostsOptions) (*threads.RepliesResponse, error) {
+	m.record("GetUserReplies", ctx, []interface{}{userID, opts})
+	if m.GetUserRepliesFunc != nil {
+		return m.GetUserRepliesFunc(ctx, userID, opts)
+	}
+	var r0 *threads.RepliesResponse
+	return r0, m.notStubbed("GetUserReplies")
+}
+
+// GetPendingReplies implements threads.ReplyManager.
+func (m *Client) GetPendingReplies(ctx context.Context, postID threads.PostID, opts *threads.PendingRepliesOptions) (*threads.RepliesResponse, error) {
+	m.record("GetPendingReplies", ctx, []interface{}{postID, opts})
+	if m.GetPendingRepliesFunc != nil {
+		return m.GetPendingRepliesFunc(ctx, postID, opts)
+	}
+	var r0 *threads.RepliesResponse
+	return r0, m.notStubbed("GetPendingReplies")
+}
+
+// ApprovePendingReply implements threads.ReplyManager.
+func (m *Client) ApprovePendingReply(ctx context.Context, replyID threads.PostID) error {
+	m.record("ApprovePendingReply", ctx, []interface{}{replyID})
+	if m.ApprovePendingReplyFunc != nil {
+		return m.ApprovePendingReplyFunc(ctx, replyID)
+	}
+	return m.notStubbed("ApprovePendingReply")
+}
+
+// IgnorePendingReply implements threads.ReplyManager.
+func (m *Client) IgnorePendingReply(ctx context.Context, replyID threads.PostID) error {
+	m.record("IgnorePendingReply", ctx, []interface{}{replyID})
+	if m.IgnorePendingReplyFunc != nil {
+		return m.IgnorePendingReplyFunc(ctx, replyID)
+	}
+	return m.notStubbed("IgnorePendingReply")
+}
+
+// GetPostInsights implements threads.InsightsProvider.
+func (m *Client) GetPostInsights(ctx context.Context, postID threads.PostID, metrics []string) (*threads.InsightsResponse, error) {
+	m.record("GetPostInsights", ctx, []interface{}{postID, metrics})
+	if m.GetPostInsightsFunc != nil {
+		return m.GetPostInsightsFunc(ctx, postID, metrics)
+	}
+	var r0 *threads.InsightsResponse
+	return r0, m.notStubbed("GetPostInsights")
+}
+
+// GetPostInsightsWithOptions implements threads.InsightsProvider.
+func (m *Client) GetPostInsightsWithOptions(ctx context.Context, postID threads.PostID, opts *threads.PostInsightsOptions) (*threads.InsightsResponse, error) {
+	m.record("GetPostInsightsWithOptions", ctx, []interface{}{postID, opts})
+	if m.GetPostInsightsWithOptionsFunc != nil {
+		return m.GetPostInsightsWithOptionsFunc(ctx, postID, opts)
+	}
+	var r0 *threads.InsightsResponse
+	return r0, m.notStubbed("GetPostInsightsWithOptions")
+}
+
+// GetAccountInsights implements threads.InsightsProvider.
+func (m *Client) GetAccountInsights(ctx context.Context, userID threads.UserID, metrics []string, period string) (*threads.InsightsResponse, error) {
+	m.record("GetAccountInsights", ctx, []interface{}{userID, metrics, period})
+	if m.GetAccountInsightsFunc != nil {
+		return m.GetAccountInsightsFunc(ctx, userID, metrics, period)
+	}
+	var r0 *threads.InsightsResponse
+	return r0, m.notStubbed("GetAccountInsights")
+}
+
+// GetAccountInsightsWithOptions implements threads.InsightsProvider.
+func (m *Client) GetAccountInsightsWithOptions(ctx context.Context, userID threads.UserID, opts *threads.AccountInsightsOptions) (*threads.InsightsResponse, error) {
+	m.record("GetAccountInsightsWithOptions", ctx, []interface{}{userID, opts})
+	if m.GetAccountInsightsWithOptionsFunc != nil {
+		return m.GetAccountInsightsWithOptionsFunc(ctx, userID, opts)
+	}
+	var r0 *threads.InsightsResponse
+	return r0, m.notStubbed("GetAccountInsightsWithOptions")
+}
+
+// SearchLocations implements threads.LocationManager.
+func (m *Client) SearchLocations(ctx context.Context, query string, latitude *float64, longitude *float64) (*threads.LocationSearchResponse, error) {
+	m.record("SearchLocations", ctx, []interface{}{query, latitude, longitude})
+	if m.SearchLocationsFunc != nil {
+		return m.SearchLocationsFunc(ctx, query, latitude, longitude)
+	}
+	var r0 *threads.LocationSearchResponse
+	return r0, m.notStubbed("SearchLocations")
+}
+
+// GetLocation implements threads.LocationManager.
+func (m *Client) GetLocation(ctx context.Context, locationID threads.LocationID) (*threads.Location, error) {
+	m.record("GetLocation", ctx, []interface{}{locationID})
+	if m.GetLocationFunc != nil {
+		return m.GetLocationFunc(ctx, locationID)
+	}
+	var r0 *threads.Location
+	return r0, m.notStubbed("GetLocation")
+}
+
+// KeywordSearch implements threads.SearchProvider.
+func (m *Client) KeywordSearch(ctx context.Context, query string, opts *threads.SearchOptions) (*threads.PostsResponse, error) {
+	m.record("KeywordSearch", ctx, []interface{}{query, opts})
+	if m.KeywordSearchFunc != nil {
+		return m.KeywordSearchFunc(ctx, query, opts)
+	}
+	var r0 *threads.PostsResponse
+	return r0, m.notStubbed("KeywordSearch")
+}
+
+// IsRateLimited implements threads.RateLimitController.
+func (m *Client) IsRateLimited() bool {
+	m.record("IsRateLimited", nil, []interface{}{})
+	if m.IsRateLimitedFunc != nil {
+		return m.IsRateLimitedFunc()
+	}
+	var r0 bool
+	return r0
+}
+
+// DisableRateLimiting implements threads.RateLimitController.
+func (m *Client) DisableRateLimiting() {
+	m.record("DisableRateLimiting", nil, []interface{}{})
+	if m.DisableRateLimitingFunc != nil {
+		m.DisableRateLimitingFunc()
+		return
+	}
+}
+
+// EnableRateLimiting implements threads.RateLimitController.
+func (m *Client) EnableRateLimiting() {
+	m.record("EnableRateLimiting", nil, []interface{}{})
+	if m.EnableRateLimitingFunc != nil {
+		m.EnableRateLimitingFunc()
+		return
+	}
+}
+
+// GetRateLimitStatus implements threads.RateLimitController.
+func (m *Client) GetRateLimitStatus() threads.RateLimitStatus {
+	m.record("GetRateLimitStatus", nil, []interface{}{})
+	if m.GetRateLimitStatusFunc != nil {
+		return m.GetRateLimitStatusFunc()
+	}
+	var r0 threads.RateLimitStatus
+	return r0
+}
+
+// IsNearRateLimit implements threads.RateLimitController.
+func (m *Client) IsNearRateLimit(threshold float64) bool {
+	m.record("IsNearRateLimit", nil, []interface{}{threshold})
+	if m.IsNearRateLimitFunc != nil {
+		return m.IsNearRateLimitFunc(threshold)
+	}
+	var r0 bool
+	return r0
+}
+
+// WaitForRateLimit implements threads.RateLimitController.
+func (m *Client) WaitForRateLimit(ctx context.Context) error {
+	m.record("WaitForRateLimit", ctx, []interface{}{})
+	if m.WaitForRateLimitFunc != nil {
+		return m.WaitForRateLimitFunc(ctx)
+	}
+	return m.notStubbed("WaitForRateLimit")
+}
@@ -0,0 +1,107 @@
+package threadsmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// fakeT captures assertion failures so the helpers themselves can be tested.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// publish stands in for application code that depends on a narrow interface.
+func publish(ctx context.Context, creator threads.PostCreator, text string) (string, error) {
+	post, err := creator.CreateTextPost(ctx, &threads.TextPostContent{Text: text})
+	if err != nil {
+		return "", err
+	}
+	return post.ID, nil
+}
+
+func TestClient_StubAndRecord(t *testing.T) {
+	mock := &Client{
+		CreateTextPostFunc: func(ctx context.Context, content *threads.TextPostContent) (*threads.Post, error) {
+			return &threads.Post{ID: "42", Text: content.Text}, nil
+		},
+	}
+
+	id, err := publish(context.Background(), mock, "hello")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "42" {
+		t.Errorf("expected stubbed ID, got %q", id)
+	}
+
+	mock.AssertNumberOfCalls(t, "CreateTextPost", 1)
+	mock.AssertCalledWith(t, "CreateTextPost", &threads.TextPostContent{Text: "hello"})
+	mock.AssertNotCalled(t, "DeletePost")
+
+	calls := mock.Calls("CreateTextPost")
+	if calls[0].Ctx == nil {
+		t.Error("expected the context to be recorded")
+	}
+}
+
+func TestClient_UnstubbedMethodReturnsErrNotStubbed(t *testing.T) {
+	mock := &Client{}
+
+	post, err := mock.GetPost(context.Background(), threads.PostID("1"))
+	if post != nil {
+		t.Errorf("expected nil post, got %+v", post)
+	}
+	if !errors.Is(err, ErrNotStubbed) {
+		t.Fatalf("expected ErrNotStubbed, got %v", err)
+	}
+
+	// Methods without an error result return zero values.
+	if mock.IsRateLimited() {
+		t.Error("expected zero value from unstubbed IsRateLimited")
+	}
+	mock.AssertCalled(t, "GetPost")
+	mock.AssertCalled(t, "IsRateLimited")
+}
+
+func TestClient_AssertCalledWithAny(t *testing.T) {
+	mock := &Client{}
+	_, _ = mock.GetAccountInsights(context.Background(), threads.UserID("7"), []string{"views"}, "day")
+
+	mock.AssertCalledWith(t, "GetAccountInsights", threads.UserID("7"), Any, "day")
+
+	ft := &fakeT{}
+	if mock.AssertCalledWith(ft, "GetAccountInsights", threads.UserID("8"), Any, "day") {
+		t.Error("expected mismatched arguments to fail")
+	}
+	if len(ft.errors) != 1 {
+		t.Errorf("expected one reported failure, got %v", ft.errors)
+	}
+}
+
+func TestClient_AssertionsReportFailures(t *testing.T) {
+	mock := &Client{}
+	ft := &fakeT{}
+
+	mock.AssertCalled(ft, "GetMe")
+	mock.AssertCalledWith(ft, "GetMe")
+	mock.AssertNumberOfCalls(ft, "GetMe", 2)
+	if len(ft.errors) != 3 {
+		t.Errorf("expected 3 failures, got %d: %v", len(ft.errors), ft.errors)
+	}
+
+	_, _ = mock.GetMe(context.Background())
+	mock.Reset()
+	if mock.CallCount("") != 0 {
+		t.Error("expected Reset to clear the call log")
+	}
+}
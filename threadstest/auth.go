@@ -0,0 +1,205 @@
+package threadstest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token lifetimes issued by the fake, matching the Threads API.
+const (
+	ShortLivedTokenLifetime = time.Hour
+	LongLivedTokenLifetime  = 60 * 24 * time.Hour
+)
+
+type fakeToken struct {
+	userID    string
+	issuedAt  time.Time
+	expiresAt time.Time
+	longLived bool
+	revoked   bool
+}
+
+// AddAuthorizationCode registers an OAuth authorization code that exchanges
+// for a short-lived token for userID.
+func (s *Server) AddAuthorizationCode(code, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.codes == nil {
+		s.codes = make(map[string]string)
+	}
+	s.codes[code] = userID
+	if _, ok := s.users[userID]; !ok {
+		s.users[userID] = &fakeUser{id: userID, username: "user" + userID}
+	}
+}
+
+// IssueToken creates a token for userID that expires after lifetime and
+// returns it. Tokens with a lifetime longer than ShortLivedTokenLifetime are
+// treated as long-lived and may be refreshed.
+func (s *Server) IssueToken(userID string, lifetime time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issueToken(userID, lifetime)
+}
+
+// RevokeToken invalidates token. Subsequent requests using it fail with
+// error code 190.
+func (s *Server) RevokeToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tokens[token]; ok {
+		t.revoked = true
+	}
+}
+
+// issueToken mints a token. Callers must hold s.mu.
+func (s *Server) issueToken(userID string, lifetime time.Duration) string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	token := "THQ" + hex.EncodeToString(buf)
+	now := s.now()
+	s.tokens[token] = &fakeToken{
+		userID:    userID,
+		issuedAt:  now,
+		expiresAt: now.Add(lifetime),
+		longLived: lifetime > ShortLivedTokenLifetime,
+	}
+	return token
+}
+
+// lookupToken returns the token record if it is usable. Callers must hold s.mu.
+func (s *Server) lookupToken(token string) (*fakeToken, bool) {
+	t, ok := s.tokens[token]
+	if !ok || t.revoked || !s.now().Before(t.expiresAt) {
+		return nil, false
+	}
+	return t, true
+}
+
+// authenticate resolves the caller's user ID from the bearer token or
+// access_token parameter, writing a 401 on failure. Callers must hold s.mu.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.Form.Get("access_token")
+	}
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, ErrCodeInvalidToken, 0, "An active access token must be used to query information about the current user.")
+		return "", false
+	}
+	t, ok := s.lookupToken(token)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, ErrCodeInvalidToken, 463, "Error validating access token: Session has expired or is invalid.")
+		return "", false
+	}
+	return t.userID, true
+}
+
+func (s *Server) handleOAuthAccessToken(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("client_id") != DefaultClientID || r.Form.Get("client_secret") != DefaultClientSecret {
+		writeError(w, http.StatusBadRequest, 101, 0, "Error validating application. Invalid application ID or secret.")
+		return
+	}
+
+	switch r.Form.Get("grant_type") {
+	case "client_credentials":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"access_token": "TH|" + DefaultClientID + "|" + DefaultClientSecret,
+			"token_type":   "bearer",
+		})
+	case "authorization_code":
+		if r.Form.Get("redirect_uri") != DefaultRedirectURI {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 36008, "Error validating verification code. Please make sure your redirect_uri is identical.")
+			return
+		}
+		userID, ok := s.codes[r.Form.Get("code")]
+		if !ok {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 36007, "Invalid verification code format.")
+			return
+		}
+		delete(s.codes, r.Form.Get("code"))
+		id, _ := strconv.ParseInt(userID, 10, 64)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": s.issueToken(userID, ShortLivedTokenLifetime),
+			"token_type":   "bearer",
+			"expires_in":   int64(ShortLivedTokenLifetime.Seconds()),
+			"user_id":      id,
+		})
+	default:
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "Unsupported grant_type")
+	}
+}
+
+func (s *Server) handleExchangeToken(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("grant_type") != "th_exchange_token" {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "Unsupported grant_type")
+		return
+	}
+	if r.Form.Get("client_secret") != DefaultClientSecret {
+		writeError(w, http.StatusBadRequest, 101, 0, "Error validating client secret.")
+		return
+	}
+	t, ok := s.lookupToken(r.Form.Get("access_token"))
+	if !ok {
+		writeError(w, http.StatusUnauthorized, ErrCodeInvalidToken, 463, "Error validating access token: Session has expired or is invalid.")
+		return
+	}
+	s.writeLongLivedToken(w, t.userID)
+}
+
+func (s *Server) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
+	if r.Form.Get("grant_type") != "th_refresh_token" {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "Unsupported grant_type")
+		return
+	}
+	t, ok := s.lookupToken(r.Form.Get("access_token"))
+	if !ok {
+		writeError(w, http.StatusUnauthorized, ErrCodeInvalidToken, 463, "Error validating access token: Session has expired or is invalid.")
+		return
+	}
+	if !t.longLived {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidToken, 0, "Only long-lived tokens can be refreshed.")
+		return
+	}
+	s.writeLongLivedToken(w, t.userID)
+}
+
+// writeLongLivedToken issues and writes a long-lived token. Callers must hold s.mu.
+func (s *Server) writeLongLivedToken(w http.ResponseWriter, userID string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": s.issueToken(userID, LongLivedTokenLifetime),
+		"token_type":   "bearer",
+		"expires_in":   int64(LongLivedTokenLifetime.Seconds()),
+	})
+}
+
+func (s *Server) handleDebugToken(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.lookupToken(r.Form.Get("access_token")); !ok {
+		writeError(w, http.StatusUnauthorized, ErrCodeInvalidToken, 463, "Error validating access token: Session has expired or is invalid.")
+		return
+	}
+
+	data := map[string]interface{}{
+		"type":        "USER",
+		"application": "threadstest",
+		"is_valid":    false,
+		"scopes":      []string{},
+	}
+	if t, ok := s.tokens[r.Form.Get("input_token")]; ok {
+		data["is_valid"] = !t.revoked && s.now().Before(t.expiresAt)
+		data["issued_at"] = t.issuedAt.Unix()
+		data["expires_at"] = t.expiresAt.Unix()
+		data["data_access_expires_at"] = t.expiresAt.Unix()
+		data["user_id"] = t.userID
+		data["scopes"] = []string{
+			"threads_basic", "threads_content_publish", "threads_manage_replies",
+			"threads_manage_insights", "threads_read_replies", "threads_keyword_search",
+			"threads_location_tagging", "threads_delete",
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
@@ -0,0 +1,341 @@
+package threadstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// containerLifetime is how long an unpublished container stays usable before
+// the API reports it as EXPIRED.
+const containerLifetime = 24 * time.Hour
+
+type fakeContainer struct {
+	id           string
+	ownerID      string
+	params       url.Values
+	status       string
+	errorMessage string
+	pollsLeft    int
+	outcome      string
+	outcomeMsg   string
+	createdAt    time.Time
+	postID       string
+}
+
+// FailContainer forces the container into the ERROR state with msg as its
+// error_message (e.g. threads.ContainerErrFailedProcessingVideo).
+func (s *Server) FailContainer(id, msg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.containers[id]
+	if !ok {
+		return fmt.Errorf("threadstest: unknown container %s", id)
+	}
+	c.status = threads.ContainerStatusError
+	c.errorMessage = msg
+	c.pollsLeft = 0
+	return nil
+}
+
+// ExpireContainer forces the container into the EXPIRED state.
+func (s *Server) ExpireContainer(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.containers[id]
+	if !ok {
+		return fmt.Errorf("threadstest: unknown container %s", id)
+	}
+	c.status = threads.ContainerStatusExpired
+	c.pollsLeft = 0
+	return nil
+}
+
+// ContainerStatus returns the current status of a container without
+// counting as a poll.
+func (s *Server) ContainerStatus(id string) (threads.ContainerStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.containers[id]
+	if !ok {
+		return threads.ContainerStatus{}, false
+	}
+	return threads.ContainerStatus{ID: c.id, Status: c.status, ErrorMessage: c.errorMessage}, true
+}
+
+// ContainerCount returns the number of containers created, including
+// published and expired ones.
+func (s *Server) ContainerCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.containers)
+}
+
+// observeContainer advances a container by one status poll. Callers must hold s.mu.
+func (s *Server) observeContainer(c *fakeContainer) {
+	if c.status == threads.ContainerStatusInProgress || c.status == threads.ContainerStatusFinished {
+		if s.now().Sub(c.createdAt) >= containerLifetime {
+			c.status = threads.ContainerStatusExpired
+			return
+		}
+	}
+	if c.status != threads.ContainerStatusInProgress {
+		return
+	}
+	if c.pollsLeft > 0 {
+		c.pollsLeft--
+		return
+	}
+	c.status = c.outcome
+	c.errorMessage = c.outcomeMsg
+}
+
+func (s *Server) handleCreateContainer(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if id != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Cannot create containers for another user", false)
+		return
+	}
+	params := r.PostForm
+	if len(params) == 0 {
+		params = r.Form
+	}
+
+	if msg := s.validateContainerParams(params, userID); msg != "" {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, msg)
+		return
+	}
+
+	if params.Get("auto_publish_text") == "true" {
+		if params.Get("media_type") != threads.MediaTypeText {
+			writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "auto_publish_text is only supported for TEXT posts")
+			return
+		}
+		if !s.consumeQuota(w, publishQuota(params)) {
+			return
+		}
+		p := s.publish(userID, cloneValues(params), nil)
+		writeJSON(w, http.StatusOK, map[string]string{"id": p.post.ID})
+		return
+	}
+
+	c := &fakeContainer{
+		id:        s.newID(),
+		ownerID:   userID,
+		params:    cloneValues(params),
+		status:    threads.ContainerStatusInProgress,
+		pollsLeft: s.opts.ProcessingPolls,
+		outcome:   threads.ContainerStatusFinished,
+		createdAt: s.now(),
+	}
+	if s.opts.ContainerOutcome != nil {
+		c.outcome, c.outcomeMsg = s.opts.ContainerOutcome(c.params)
+		if c.outcome == "" {
+			c.outcome = threads.ContainerStatusFinished
+		}
+	}
+	s.containers[c.id] = c
+	writeJSON(w, http.StatusOK, map[string]string{"id": c.id})
+}
+
+// validateContainerParams mirrors the API's synchronous checks on container
+// creation and returns a non-empty message when the request is rejected.
+func (s *Server) validateContainerParams(params url.Values, userID string) string {
+	mediaType := params.Get("media_type")
+	switch mediaType {
+	case threads.MediaTypeText:
+		if params.Get("text") == "" && params.Get("poll_attachment") == "" && params.Get("gif_attachment") == "" {
+			return "Param text is required for TEXT posts"
+		}
+	case threads.MediaTypeImage:
+		if params.Get("image_url") == "" {
+			return "Param image_url is required for IMAGE containers"
+		}
+	case threads.MediaTypeVideo:
+		if params.Get("video_url") == "" {
+			return "Param video_url is required for VIDEO containers"
+		}
+	case threads.MediaTypeCarousel:
+		children := strings.Split(params.Get("children"), ",")
+		if params.Get("children") == "" || len(children) < 2 {
+			return "Carousel containers require at least 2 children"
+		}
+		for _, childID := range children {
+			child, ok := s.containers[childID]
+			if !ok || child.ownerID != userID {
+				return fmt.Sprintf("Invalid carousel child %s", childID)
+			}
+			if child.params.Get("is_carousel_item") != "true" {
+				return fmt.Sprintf("Container %s was not created as a carousel item", childID)
+			}
+		}
+	default:
+		return fmt.Sprintf("Unsupported media_type %q", mediaType)
+	}
+
+	if replyTo := params.Get("reply_to_id"); replyTo != "" {
+		if _, ok := s.posts[replyTo]; !ok {
+			return fmt.Sprintf("Cannot reply to unknown post %s", replyTo)
+		}
+	}
+	if quoted := params.Get("quote_post_id"); quoted != "" {
+		if _, ok := s.posts[quoted]; !ok {
+			return fmt.Sprintf("Cannot quote unknown post %s", quoted)
+		}
+	}
+	if loc := params.Get("location_id"); loc != "" {
+		if _, ok := s.locations[loc]; !ok {
+			return fmt.Sprintf("Unknown location_id %s", loc)
+		}
+	}
+	return ""
+}
+
+func (s *Server) handlePublish(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if r.Method != http.MethodPost {
+		writeNotFound(w)
+		return
+	}
+	if id != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Cannot publish containers for another user", false)
+		return
+	}
+
+	creationID := r.Form.Get("creation_id")
+	c, ok := s.containers[creationID]
+	if !ok || c.ownerID != userID {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, fmt.Sprintf("Invalid creation_id %s", creationID))
+		return
+	}
+	if c.params.Get("is_carousel_item") == "true" {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "Carousel items cannot be published directly")
+		return
+	}
+
+	s.observeContainer(c)
+	switch c.status {
+	case threads.ContainerStatusFinished:
+	case threads.ContainerStatusPublished:
+		writeError(w, http.StatusBadRequest, ErrCodeMediaNotReady, 0, fmt.Sprintf("Container %s has already been published", c.id))
+		return
+	default:
+		writeError(w, http.StatusBadRequest, ErrCodeMediaNotReady, 2207027,
+			fmt.Sprintf("Media is not ready to be published: container status is %s", c.status))
+		return
+	}
+
+	var children []*fakeContainer
+	if c.params.Get("media_type") == threads.MediaTypeCarousel {
+		for _, childID := range strings.Split(c.params.Get("children"), ",") {
+			child := s.containers[childID]
+			s.observeContainer(child)
+			if child.status != threads.ContainerStatusFinished {
+				writeError(w, http.StatusBadRequest, ErrCodeMediaNotReady, 2207027,
+					fmt.Sprintf("Carousel item %s is not ready to be published: status is %s", child.id, child.status))
+				return
+			}
+			children = append(children, child)
+		}
+	}
+
+	if !s.consumeQuota(w, publishQuota(c.params)) {
+		return
+	}
+
+	p := s.publish(userID, c.params, children)
+	c.status = threads.ContainerStatusPublished
+	c.postID = p.post.ID
+	for _, child := range children {
+		child.status = threads.ContainerStatusPublished
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"id": p.post.ID})
+}
+
+// publishQuota returns the quota consumed by publishing a container.
+func publishQuota(params url.Values) Quota {
+	if params.Get("reply_to_id") != "" {
+		return QuotaReplies
+	}
+	return QuotaPosts
+}
+
+// publish turns container parameters into a stored post. Callers must hold s.mu.
+func (s *Server) publish(userID string, params url.Values, children []*fakeContainer) *fakePost {
+	id := s.newID()
+	post := threads.Post{
+		ID:                id,
+		Text:              params.Get("text"),
+		Permalink:         "https://www.threads.net/@" + s.username(userID) + "/post/" + id,
+		Timestamp:         threads.Time{Time: s.now().UTC().Truncate(time.Second)},
+		Shortcode:         id,
+		MediaProductType:  "THREADS",
+		AltText:           params.Get("alt_text"),
+		LinkAttachmentURL: params.Get("link_attachment"),
+		TopicTag:          params.Get("topic_tag"),
+		LocationID:        params.Get("location_id"),
+		IsSpoilerMedia:    params.Get("is_spoiler_media") == "true",
+		ReplyAudience:     threads.ReplyAudience(strings.ToUpper(params.Get("reply_control"))),
+	}
+	if codes := params["allowlisted_country_codes"]; len(codes) > 0 {
+		post.AllowlistedCountryCodes = append([]string(nil), codes...)
+	}
+
+	switch params.Get("media_type") {
+	case threads.MediaTypeText:
+		post.MediaType = threads.MediaTypeResponseText
+	case threads.MediaTypeImage:
+		post.MediaType = threads.MediaTypeImage
+		post.MediaURL = params.Get("image_url")
+	case threads.MediaTypeVideo:
+		post.MediaType = threads.MediaTypeVideo
+		post.MediaURL = params.Get("video_url")
+	case threads.MediaTypeCarousel:
+		post.MediaType = threads.MediaTypeResponseCarousel
+		post.Children = &threads.ChildrenData{}
+		for _, child := range children {
+			post.Children.Data = append(post.Children.Data, threads.ChildPost{ID: child.id})
+		}
+	}
+
+	if quoted := params.Get("quote_post_id"); quoted != "" {
+		post.IsQuotePost = true
+		post.QuotedPost = &threads.Post{ID: quoted}
+	}
+	if params.Get("is_ghost_post") == "true" {
+		post.GhostPostStatus = "active"
+		post.GhostPostExpirationTimestamp = threads.Time{Time: post.Timestamp.Add(24 * time.Hour)}
+	}
+
+	p := &fakePost{
+		post:           post,
+		ownerID:        userID,
+		replyApprovals: params.Get("enable_reply_approvals") == "true",
+		insights:       make(map[string]int),
+	}
+	if parentID := params.Get("reply_to_id"); parentID != "" {
+		s.attachReply(p, parentID)
+	}
+	s.posts[id] = p
+	s.postOrder = append(s.postOrder, id)
+	return p
+}
+
+// username returns the username registered for userID. Callers must hold s.mu.
+func (s *Server) username(userID string) string {
+	if u, ok := s.users[userID]; ok {
+		return u.username
+	}
+	return "user" + userID
+}
+
+func atoiDefault(v string, def int) int {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,573 @@
+package threadstest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// defaultPageSize is the page size used when a request has no limit.
+const defaultPageSize = 25
+
+type fakePost struct {
+	post           threads.Post
+	ownerID        string
+	parentID       string
+	rootID         string
+	hidden         bool
+	approval       string // "", "pending" or "ignored"
+	replyApprovals bool
+	insights       map[string]int
+}
+
+// PostSeed describes a post or reply created directly in the fake's state,
+// bypassing the container flow. It is typically used to simulate activity
+// by other users.
+type PostSeed struct {
+	// AuthorID is the author's user ID. Unknown IDs are registered with a
+	// generated username. Default: the server's default user.
+	AuthorID string
+
+	// ReplyTo makes the seed a reply to an existing post.
+	ReplyTo string
+
+	Text      string
+	MediaType string // Default: threads.MediaTypeText
+	MediaURL  string
+	TopicTag  string
+
+	// EnableReplyApprovals holds replies from other users for approval.
+	EnableReplyApprovals bool
+
+	// Timestamp defaults to the server's current time.
+	Timestamp time.Time
+}
+
+// AddPost stores a published post or reply and returns its ID. Replies from
+// other users to a post with reply approvals enabled start out pending.
+func (s *Server) AddPost(seed PostSeed) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seed.AuthorID == "" {
+		seed.AuthorID = s.opts.UserID
+	}
+	if _, ok := s.users[seed.AuthorID]; !ok {
+		s.users[seed.AuthorID] = &fakeUser{id: seed.AuthorID, username: "user" + seed.AuthorID}
+	}
+	if seed.ReplyTo != "" {
+		if _, ok := s.posts[seed.ReplyTo]; !ok {
+			return "", fmt.Errorf("threadstest: unknown post %s", seed.ReplyTo)
+		}
+	}
+
+	params := url.Values{"text": {seed.Text}, "topic_tag": {seed.TopicTag}}
+	switch seed.MediaType {
+	case "", threads.MediaTypeText, threads.MediaTypeResponseText:
+		params.Set("media_type", threads.MediaTypeText)
+	case threads.MediaTypeImage:
+		params.Set("media_type", threads.MediaTypeImage)
+		params.Set("image_url", seed.MediaURL)
+	case threads.MediaTypeVideo:
+		params.Set("media_type", threads.MediaTypeVideo)
+		params.Set("video_url", seed.MediaURL)
+	default:
+		return "", fmt.Errorf("threadstest: unsupported seed media type %q", seed.MediaType)
+	}
+	if seed.ReplyTo != "" {
+		params.Set("reply_to_id", seed.ReplyTo)
+	}
+	if seed.EnableReplyApprovals {
+		params.Set("enable_reply_approvals", "true")
+	}
+
+	p := s.publish(seed.AuthorID, params, nil)
+	if !seed.Timestamp.IsZero() {
+		p.post.Timestamp = threads.Time{Time: seed.Timestamp.UTC()}
+	}
+	return p.post.ID, nil
+}
+
+// SetPostInsights sets the metric values returned for a post.
+func (s *Server) SetPostInsights(postID string, values map[string]int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.posts[postID]
+	if !ok {
+		return fmt.Errorf("threadstest: unknown post %s", postID)
+	}
+	for k, v := range values {
+		p.insights[k] = v
+	}
+	return nil
+}
+
+// Post returns a snapshot of a stored post as the default user sees it.
+func (s *Server) Post(id string) (threads.Post, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.posts[id]
+	if !ok {
+		return threads.Post{}, false
+	}
+	return s.view(p, s.opts.UserID), true
+}
+
+// PostCount returns the number of stored posts and replies.
+func (s *Server) PostCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.posts)
+}
+
+// attachReply links a new reply to its parent. Callers must hold s.mu.
+func (s *Server) attachReply(p *fakePost, parentID string) {
+	parent := s.posts[parentID]
+	p.parentID = parentID
+	p.rootID = parentID
+	if parent.rootID != "" {
+		p.rootID = parent.rootID
+	}
+	p.post.IsReply = true
+	p.post.ReplyTo = parentID
+
+	root := s.posts[p.rootID]
+	if root.replyApprovals && p.ownerID != root.ownerID {
+		p.approval = string(threads.ApprovalStatusPending)
+	}
+}
+
+// view renders a post as seen by viewerID. Callers must hold s.mu.
+func (s *Server) view(p *fakePost, viewerID string) threads.Post {
+	post := p.post
+	owner := s.users[p.ownerID]
+	post.Username = s.username(p.ownerID)
+	post.Owner = &threads.PostOwner{ID: p.ownerID}
+	if owner != nil {
+		post.IsVerified = owner.verified
+	}
+	post.HasReplies = len(s.visibleReplies(p.post.ID)) > 0
+	post.ReplyApprovalStatus = p.approval
+
+	if p.parentID != "" {
+		post.RepliedTo = &threads.Post{ID: p.parentID}
+		post.RootPost = &threads.Post{ID: p.rootID}
+		post.IsReplyOwnedByMe = p.ownerID == viewerID
+		post.HideStatus = threads.HideStatusNotHushed
+		if p.hidden {
+			post.HideStatus = threads.HideStatusHidden
+		}
+	}
+	if post.LocationID != "" {
+		if loc, ok := s.locations[post.LocationID]; ok {
+			l := *loc
+			post.Location = &l
+		}
+	}
+	return post
+}
+
+// visibleReplies returns the IDs of approved direct replies to postID in
+// creation order. Callers must hold s.mu.
+func (s *Server) visibleReplies(postID string) []string {
+	var ids []string
+	for _, id := range s.postOrder {
+		p := s.posts[id]
+		if p != nil && p.parentID == postID && p.approval == "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *Server) handleUserPosts(w http.ResponseWriter, r *http.Request, userID string) {
+	if _, ok := s.users[userID]; !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writePosts(w, r, userID, func(p *fakePost) bool {
+		return p.ownerID == userID && p.parentID == "" && p.post.GhostPostStatus == ""
+	})
+}
+
+func (s *Server) handleUserReplies(w http.ResponseWriter, r *http.Request, userID string) {
+	s.writePosts(w, r, userID, func(p *fakePost) bool {
+		return p.ownerID == userID && p.parentID != ""
+	})
+}
+
+func (s *Server) handleGhostPosts(w http.ResponseWriter, r *http.Request, userID string) {
+	s.writePosts(w, r, userID, func(p *fakePost) bool {
+		return p.ownerID == userID && p.post.GhostPostStatus != ""
+	})
+}
+
+func (s *Server) handleMentions(w http.ResponseWriter, r *http.Request, userID string) {
+	mention := "@" + strings.ToLower(s.username(userID))
+	s.writePosts(w, r, userID, func(p *fakePost) bool {
+		return p.ownerID != userID && strings.Contains(strings.ToLower(p.post.Text), mention)
+	})
+}
+
+func (s *Server) handlePostReplies(w http.ResponseWriter, r *http.Request, postID string) {
+	if _, ok := s.posts[postID]; !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writePosts(w, r, s.opts.UserID, func(p *fakePost) bool {
+		return p.parentID == postID && p.approval == ""
+	})
+}
+
+func (s *Server) handleConversation(w http.ResponseWriter, r *http.Request, postID string) {
+	if _, ok := s.posts[postID]; !ok {
+		writeNotFound(w)
+		return
+	}
+	s.writePosts(w, r, s.opts.UserID, func(p *fakePost) bool {
+		return p.approval == "" && s.descendsFrom(p, postID)
+	})
+}
+
+// descendsFrom reports whether p is a reply somewhere below ancestorID.
+// Callers must hold s.mu.
+func (s *Server) descendsFrom(p *fakePost, ancestorID string) bool {
+	for p.parentID != "" {
+		if p.parentID == ancestorID {
+			return true
+		}
+		parent, ok := s.posts[p.parentID]
+		if !ok {
+			return false
+		}
+		p = parent
+	}
+	return false
+}
+
+func (s *Server) handlePendingReplies(w http.ResponseWriter, r *http.Request, userID, postID string) {
+	root, ok := s.posts[postID]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if root.ownerID != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Only the post owner can view pending replies", false)
+		return
+	}
+	status := r.Form.Get("approval_status")
+	if status == "" {
+		status = string(threads.ApprovalStatusPending)
+	}
+	s.writePosts(w, r, userID, func(p *fakePost) bool {
+		return p.approval == status && s.descendsFrom(p, postID)
+	})
+}
+
+func (s *Server) handleManageReply(w http.ResponseWriter, r *http.Request, userID, replyID string) {
+	reply, ok := s.posts[replyID]
+	if !ok || reply.parentID == "" {
+		writeNotFound(w)
+		return
+	}
+	if s.posts[reply.rootID].ownerID != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Only the owner of the root post can manage replies", false)
+		return
+	}
+	reply.hidden = r.Form.Get("hide") == "true"
+	writeSuccess(w)
+}
+
+func (s *Server) handleManagePendingReply(w http.ResponseWriter, r *http.Request, userID, replyID string) {
+	reply, ok := s.posts[replyID]
+	if !ok || reply.approval == "" {
+		writeNotFound(w)
+		return
+	}
+	if s.posts[reply.rootID].ownerID != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Only the owner of the root post can manage pending replies", false)
+		return
+	}
+	if r.Form.Get("approve") == "true" {
+		reply.approval = ""
+	} else {
+		reply.approval = string(threads.ApprovalStatusIgnored)
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) handleRepost(w http.ResponseWriter, r *http.Request, userID, postID string) {
+	original, ok := s.posts[postID]
+	if !ok || r.Method != http.MethodPost {
+		writeNotFound(w)
+		return
+	}
+	if !s.consumeQuota(w, QuotaPosts) {
+		return
+	}
+	id := s.newID()
+	repost := &fakePost{
+		post: threads.Post{
+			ID:               id,
+			MediaType:        threads.MediaTypeRepostFacade,
+			MediaProductType: "THREADS",
+			Permalink:        original.post.Permalink,
+			Timestamp:        threads.Time{Time: s.now().UTC().Truncate(time.Second)},
+			RepostedPost:     &threads.Post{ID: postID},
+		},
+		ownerID:  userID,
+		insights: make(map[string]int),
+	}
+	s.posts[id] = repost
+	s.postOrder = append(s.postOrder, id)
+	original.insights["reposts"]++
+	writeJSON(w, http.StatusOK, map[string]string{"id": id})
+}
+
+func (s *Server) handleDeletePost(w http.ResponseWriter, r *http.Request, userID, postID string) {
+	p, ok := s.posts[postID]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if p.ownerID != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Cannot delete a post owned by another user", false)
+		return
+	}
+	if !s.consumeQuota(w, QuotaDeletes) {
+		return
+	}
+	delete(s.posts, postID)
+	for i, id := range s.postOrder {
+		if id == postID {
+			s.postOrder = append(s.postOrder[:i], s.postOrder[i+1:]...)
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true, "deleted_id": postID})
+}
+
+func (s *Server) handlePostInsights(w http.ResponseWriter, r *http.Request, postID string) {
+	p, ok := s.posts[postID]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeInsights(w, r, p.insights, "lifetime", postID)
+}
+
+func (s *Server) handleAccountInsights(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if id != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Cannot read insights for another user", false)
+		return
+	}
+	period := r.Form.Get("period")
+	if period == "" {
+		period = "lifetime"
+	}
+	writeInsights(w, r, s.accountInsights, period, id)
+}
+
+func writeInsights(w http.ResponseWriter, r *http.Request, values map[string]int, period, objectID string) {
+	metrics := strings.Split(r.Form.Get("metric"), ",")
+	data := make([]threads.Insight, 0, len(metrics))
+	for _, m := range metrics {
+		if m == "" {
+			continue
+		}
+		data = append(data, threads.Insight{
+			Name:       m,
+			Period:     period,
+			Values:     []threads.Value{{Value: values[m]}},
+			Title:      m,
+			ID:         objectID + "/insights/" + m + "/" + period,
+			TotalValue: &threads.TotalValue{Value: values[m]},
+		})
+	}
+	writeJSON(w, http.StatusOK, threads.InsightsResponse{Data: data})
+}
+
+func (s *Server) handleKeywordSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.consumeQuota(w, QuotaSearches) {
+		return
+	}
+	q := strings.ToLower(r.Form.Get("q"))
+	tagMode := r.Form.Get("search_mode") == string(threads.SearchModeTag)
+	mediaType := r.Form.Get("media_type")
+	author := r.Form.Get("author_username")
+
+	s.writePosts(w, r, s.opts.UserID, func(p *fakePost) bool {
+		if p.approval != "" {
+			return false
+		}
+		if tagMode {
+			if strings.ToLower(strings.TrimPrefix(p.post.TopicTag, "#")) != strings.TrimPrefix(q, "#") {
+				return false
+			}
+		} else if !strings.Contains(strings.ToLower(p.post.Text), q) {
+			return false
+		}
+		if mediaType != "" && !matchesMediaType(p.post.MediaType, mediaType) {
+			return false
+		}
+		return author == "" || s.username(p.ownerID) == author
+	})
+}
+
+func matchesMediaType(postType, filter string) bool {
+	if filter == threads.MediaTypeText {
+		return postType == threads.MediaTypeResponseText
+	}
+	return postType == filter
+}
+
+func (s *Server) handleLocationSearch(w http.ResponseWriter, r *http.Request) {
+	if !s.consumeQuota(w, QuotaLocationSearches) {
+		return
+	}
+	q := strings.ToLower(r.Form.Get("q"))
+	lat, latErr := strconv.ParseFloat(r.Form.Get("latitude"), 64)
+	lon, lonErr := strconv.ParseFloat(r.Form.Get("longitude"), 64)
+	byCoords := latErr == nil && lonErr == nil
+
+	results := []threads.Location{}
+	for _, id := range s.locationOrder {
+		loc := s.locations[id]
+		if q != "" && !strings.Contains(strings.ToLower(loc.Name), q) {
+			continue
+		}
+		results = append(results, *loc)
+	}
+	if byCoords {
+		dist := func(l threads.Location) float64 {
+			return math.Hypot(l.Latitude-lat, l.Longitude-lon)
+		}
+		sort.SliceStable(results, func(i, j int) bool { return dist(results[i]) < dist(results[j]) })
+	}
+	writeJSON(w, http.StatusOK, threads.LocationSearchResponse{Data: results})
+}
+
+func (s *Server) handleProfileLookup(w http.ResponseWriter, r *http.Request) {
+	u := s.userByName(r.Form.Get("username"))
+	if u == nil {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, threads.PublicUser{
+		Username:      u.username,
+		Name:          u.name,
+		Biography:     u.biography,
+		IsVerified:    u.verified,
+		FollowerCount: u.followers,
+	})
+}
+
+func (s *Server) handleProfilePosts(w http.ResponseWriter, r *http.Request) {
+	u := s.userByName(r.Form.Get("username"))
+	if u == nil {
+		writeNotFound(w)
+		return
+	}
+	s.writePosts(w, r, s.opts.UserID, func(p *fakePost) bool {
+		return p.ownerID == u.id && p.parentID == "" && p.post.GhostPostStatus == ""
+	})
+}
+
+// userByName looks up a user by username. Callers must hold s.mu.
+func (s *Server) userByName(username string) *fakeUser {
+	for _, u := range s.users {
+		if u.username == username {
+			return u
+		}
+	}
+	return nil
+}
+
+// writePosts writes a page of posts matching keep, newest first unless the
+// request sets reverse=false. Callers must hold s.mu.
+func (s *Server) writePosts(w http.ResponseWriter, r *http.Request, viewerID string, keep func(*fakePost) bool) {
+	since, _ := strconv.ParseInt(r.Form.Get("since"), 10, 64)
+	until, _ := strconv.ParseInt(r.Form.Get("until"), 10, 64)
+
+	var matched []*fakePost
+	for _, id := range s.postOrder {
+		p := s.posts[id]
+		if !keep(p) {
+			continue
+		}
+		ts := p.post.Timestamp.Unix()
+		if (since > 0 && ts < since) || (until > 0 && ts > until) {
+			continue
+		}
+		matched = append(matched, p)
+	}
+	if r.Form.Get("reverse") != "false" {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	start, end, paging := paginate(len(matched), r.Form)
+	data := make([]threads.Post, 0, end-start)
+	for _, p := range matched[start:end] {
+		data = append(data, s.view(p, viewerID))
+	}
+	writeJSON(w, http.StatusOK, &threads.PostsResponse{Data: data, Paging: paging})
+}
+
+// paginate resolves limit/after/before against n items. Cursors are opaque
+// to clients but are simply offsets here.
+func paginate(n int, form url.Values) (start, end int, paging threads.Paging) {
+	limit := atoiDefault(form.Get("limit"), defaultPageSize)
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	switch {
+	case form.Get("after") != "":
+		start = clamp(decodeCursor(form.Get("after")), 0, n)
+		end = clamp(start+limit, 0, n)
+	case form.Get("before") != "":
+		end = clamp(decodeCursor(form.Get("before")), 0, n)
+		start = clamp(end-limit, 0, n)
+	default:
+		end = clamp(limit, 0, n)
+	}
+
+	cursors := &threads.PagingCursors{}
+	if start > 0 {
+		cursors.Before = encodeCursor(start)
+	}
+	if end < n {
+		cursors.After = encodeCursor(end)
+	}
+	if cursors.Before != "" || cursors.After != "" {
+		paging.Cursors = cursors
+	}
+	return start, end, paging
+}
+
+func encodeCursor(offset int) string {
+	return "cursor-" + strconv.Itoa(offset)
+}
+
+func decodeCursor(cursor string) int {
+	return atoiDefault(strings.TrimPrefix(cursor, "cursor-"), 0)
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,709 @@
+// Package threadstest provides an in-memory fake of the Threads Graph API for
+// testing code built on threads.ClientInterface without network access.
+//
+// The fake is stateful. Media containers move through IN_PROGRESS, FINISHED,
+// PUBLISHED, ERROR and EXPIRED; published posts can be read, replied to,
+// hidden, approved, searched and deleted; and the 24-hour publishing quotas
+// reported by GetPublishingLimits are enforced. Faults such as 429s, 5xx
+// responses and transient errors can be injected to exercise retry handling.
+//
+//	srv := threadstest.NewServer(nil)
+//	defer srv.Close()
+//
+//	client, err := srv.NewClient()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	post, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "hello"})
+package threadstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+// Defaults used by NewServer when the corresponding Options field is empty.
+const (
+	DefaultUserID       = "12345"
+	DefaultUsername     = "threadstest"
+	DefaultAccessToken  = "test-access-token"
+	DefaultClientID     = "test-client-id"
+	DefaultClientSecret = "test-client-secret"
+	DefaultRedirectURI  = "https://example.com/callback"
+)
+
+// Graph API error codes returned by the fake.
+const (
+	ErrCodeInvalidToken     = 190  // Invalid or expired OAuth access token
+	ErrCodeRateLimit        = 4    // Application request limit reached
+	ErrCodeInvalidParameter = 100  // Invalid parameter
+	ErrCodeMediaNotReady    = 9007 // Media container is not ready to be published
+	ErrCodeUnknown          = 1    // Unknown error
+	ErrCodeServiceError     = 2    // Temporary service error
+)
+
+// Quota identifies one of the publishing quotas enforced by the fake.
+type Quota string
+
+// Quotas reported by GetPublishingLimits.
+const (
+	QuotaPosts            Quota = "posts"
+	QuotaReplies          Quota = "replies"
+	QuotaDeletes          Quota = "deletes"
+	QuotaSearches         Quota = "searches"
+	QuotaLocationSearches Quota = "location_searches"
+)
+
+// Quotas configures the per-window limits enforced by the fake.
+// Zero values fall back to the documented API defaults.
+type Quotas struct {
+	Posts            int           // Default: 250
+	Replies          int           // Default: 1000
+	Deletes          int           // Default: 100
+	Searches         int           // Default: 2200
+	LocationSearches int           // Default: 500
+	Window           time.Duration // Default: 24 hours
+}
+
+// Options configures a fake server. A nil *Options uses all defaults.
+type Options struct {
+	// UserID, Username and AccessToken identify the authenticated user that
+	// NewClient signs in as. Defaults: DefaultUserID, DefaultUsername,
+	// DefaultAccessToken.
+	UserID      string
+	Username    string
+	AccessToken string
+
+	// ProcessingPolls is the number of status checks that report IN_PROGRESS
+	// before a new container settles on its outcome. Default: 0.
+	ProcessingPolls int
+
+	// ContainerOutcome decides the terminal status of each new container from
+	// its creation parameters. Return threads.ContainerStatusError with an
+	// error message (e.g. threads.ContainerErrInvalidAspectRatio) to simulate
+	// processing failures. If nil, every container finishes successfully.
+	ContainerOutcome func(params url.Values) (status, errorMessage string)
+
+	// Quotas overrides the enforced publishing quotas.
+	Quotas Quotas
+
+	// Now returns the current time. Override it to test quota windows and
+	// container expiry deterministically. Default: time.Now.
+	Now func() time.Time
+}
+
+// Fault describes an error response to inject for matching requests.
+type Fault struct {
+	// Method restricts the fault to one HTTP method. Empty matches any.
+	Method string
+
+	// Path restricts the fault to one request path (without the version
+	// prefix), e.g. "/12345/threads_publish". A trailing "*" matches by
+	// prefix. Empty matches any path.
+	Path string
+
+	// Status is the HTTP status code. Default: 500.
+	Status int
+
+	// Code, Subcode, Message and Transient populate the Graph error body.
+	Code      int
+	Subcode   int
+	Message   string
+	Transient bool
+
+	// RetryAfter, when positive, is sent as the Retry-After header, rounded
+	// up to whole seconds.
+	RetryAfter time.Duration
+
+	// Times is how many matching requests fail. Default: 1.
+	Times int
+
+	// Applied makes the fake process the request before responding with
+	// the fault, as when a response is lost after the server acted on it.
+	Applied bool
+}
+
+// RateLimitFault returns a Fault that responds with HTTP 429.
+func RateLimitFault(retryAfter time.Duration) Fault {
+	return Fault{
+		Status:     http.StatusTooManyRequests,
+		Code:       ErrCodeRateLimit,
+		Message:    "Application request limit reached",
+		RetryAfter: retryAfter,
+	}
+}
+
+// ServerErrorFault returns a Fault that responds with the given 5xx status.
+func ServerErrorFault(status int) Fault {
+	return Fault{
+		Status:  status,
+		Code:    ErrCodeUnknown,
+		Message: "An unknown error has occurred.",
+	}
+}
+
+// TransientFault returns a Fault that responds with a transient Graph error.
+func TransientFault() Fault {
+	return Fault{
+		Status:    http.StatusServiceUnavailable,
+		Code:      ErrCodeServiceError,
+		Message:   "An unexpected error has occurred. Please retry your request later.",
+		Transient: true,
+	}
+}
+
+// Request is a record of a request received by the fake.
+type Request struct {
+	Method string
+	Path   string
+	Form   url.Values
+}
+
+// Server is a stateful fake of the Threads Graph API backed by httptest.
+// It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the fake, suitable for threads.Config.BaseURL.
+	URL string
+
+	httpServer *httptest.Server
+	now        func() time.Time
+
+	mu              sync.Mutex
+	opts            Options
+	quotas          Quotas
+	nextID          int64
+	users           map[string]*fakeUser
+	tokens          map[string]*fakeToken
+	containers      map[string]*fakeContainer
+	posts           map[string]*fakePost
+	postOrder       []string
+	codes           map[string]string
+	locations       map[string]*threads.Location
+	locationOrder   []string
+	accountInsights map[string]int
+	usage           map[Quota][]time.Time
+	faults          []*Fault
+	requests        []Request
+}
+
+type fakeUser struct {
+	id        string
+	username  string
+	name      string
+	biography string
+	verified  bool
+	followers int
+}
+
+// NewServer starts a fake Threads API server. Callers must call Close when done.
+func NewServer(opts *Options) *Server {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.UserID == "" {
+		o.UserID = DefaultUserID
+	}
+	if o.Username == "" {
+		o.Username = DefaultUsername
+	}
+	if o.AccessToken == "" {
+		o.AccessToken = DefaultAccessToken
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+
+	s := &Server{
+		now:             o.Now,
+		opts:            o,
+		quotas:          withDefaultQuotas(o.Quotas),
+		nextID:          17800000000000000,
+		users:           make(map[string]*fakeUser),
+		tokens:          make(map[string]*fakeToken),
+		containers:      make(map[string]*fakeContainer),
+		posts:           make(map[string]*fakePost),
+		locations:       make(map[string]*threads.Location),
+		accountInsights: make(map[string]int),
+		usage:           make(map[Quota][]time.Time),
+	}
+
+	s.users[o.UserID] = &fakeUser{id: o.UserID, username: o.Username, name: o.Username}
+	now := s.now()
+	s.tokens[o.AccessToken] = &fakeToken{
+		userID:    o.UserID,
+		issuedAt:  now,
+		expiresAt: now.Add(60 * 24 * time.Hour),
+		longLived: true,
+	}
+
+	s.httpServer = httptest.NewServer(s)
+	s.URL = s.httpServer.URL
+	return s
+}
+
+func withDefaultQuotas(q Quotas) Quotas {
+	if q.Posts <= 0 {
+		q.Posts = 250
+	}
+	if q.Replies <= 0 {
+		q.Replies = 1000
+	}
+	if q.Deletes <= 0 {
+		q.Deletes = 100
+	}
+	if q.Searches <= 0 {
+		q.Searches = 2200
+	}
+	if q.LocationSearches <= 0 {
+		q.LocationSearches = 500
+	}
+	if q.Window <= 0 {
+		q.Window = 24 * time.Hour
+	}
+	return q
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// UserID returns the ID of the default authenticated user.
+func (s *Server) UserID() string {
+	return s.opts.UserID
+}
+
+// AccessToken returns the default user's access token.
+func (s *Server) AccessToken() string {
+	return s.opts.AccessToken
+}
+
+// Config returns a valid threads.Config pointed at the fake, with short
+// retry delays suited to tests.
+func (s *Server) Config() *threads.Config {
+	config := threads.NewConfig()
+	config.ClientID = DefaultClientID
+	config.ClientSecret = DefaultClientSecret
+	config.RedirectURI = DefaultRedirectURI
+	config.BaseURL = s.URL
+	config.RetryConfig = &threads.RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  10 * time.Millisecond,
+		MaxDelay:      50 * time.Millisecond,
+		BackoffFactor: 2.0,
+	}
+	return config
+}
+
+// NewClient returns a *threads.Client pointed at the fake and authenticated
+// as the default user.
+func (s *Server) NewClient() (*threads.Client, error) {
+	return s.NewClientWithConfig(s.Config())
+}
+
+// NewClientWithConfig returns a *threads.Client built from config and
+// authenticated as the default user. config.BaseURL is set to the fake's URL.
+func (s *Server) NewClientWithConfig(config *threads.Config) (*threads.Client, error) {
+	config.BaseURL = s.URL
+	client, err := threads.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	tok := s.tokens[s.opts.AccessToken]
+	s.mu.Unlock()
+
+	err = client.SetTokenInfo(&threads.TokenInfo{
+		AccessToken: s.opts.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   tok.expiresAt,
+		UserID:      s.opts.UserID,
+		CreatedAt:   tok.issuedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// AddUser registers another user (e.g. an author of replies or mentions).
+func (s *Server) AddUser(id, username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[id] = &fakeUser{id: id, username: username, name: username}
+}
+
+// AddLocation registers a location for SearchLocations and GetLocation and
+// returns its ID. If loc.ID is empty a new ID is assigned.
+func (s *Server) AddLocation(loc threads.Location) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if loc.ID == "" {
+		loc.ID = s.newID()
+	}
+	s.locations[loc.ID] = &loc
+	s.locationOrder = append(s.locationOrder, loc.ID)
+	return loc.ID
+}
+
+// SetAccountInsights sets the values returned for account-level metrics.
+func (s *Server) SetAccountInsights(values map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range values {
+		s.accountInsights[k] = v
+	}
+}
+
+// InjectFault queues f. Matching requests receive the fault's error response
+// instead of being handled, until f.Times requests have matched.
+func (s *Server) InjectFault(f Fault) {
+	if f.Status == 0 {
+		f.Status = http.StatusInternalServerError
+	}
+	if f.Code == 0 {
+		f.Code = ErrCodeUnknown
+	}
+	if f.Message == "" {
+		f.Message = http.StatusText(f.Status)
+	}
+	if f.Times <= 0 {
+		f.Times = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns a copy of all requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+// RequestCount returns the number of received requests matching method and
+// path. Empty method or path match anything.
+func (s *Server) RequestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if (method == "" || r.Method == method) && (path == "" || r.Path == path) {
+			n++
+		}
+	}
+	return n
+}
+
+// Usage returns how much of quota has been consumed in the current window.
+func (s *Server) Usage(quota Quota) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.usageLocked(quota)
+}
+
+var versionPrefix = regexp.MustCompile(`^/v\d+\.\d+`)
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, ErrCodeInvalidParameter, 0, "Malformed request body")
+		return
+	}
+	path := versionPrefix.ReplaceAllString(r.URL.Path, "")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Form: cloneValues(r.Form)})
+
+	if f := s.takeFault(r.Method, path); f != nil {
+		if f.Applied {
+			s.route(httptest.NewRecorder(), r, path)
+		}
+		if f.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+		}
+		writeGraphError(w, f.Status, f.Code, f.Subcode, f.Message, f.Transient)
+		return
+	}
+
+	s.route(w, r, path)
+}
+
+// route dispatches a request to its handler. Callers must hold s.mu.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, path string) {
+	// Token endpoints authenticate via their own parameters.
+	switch path {
+	case "/oauth/access_token":
+		s.handleOAuthAccessToken(w, r)
+		return
+	case "/access_token":
+		s.handleExchangeToken(w, r)
+		return
+	case "/refresh_access_token":
+		s.handleRefreshToken(w, r)
+		return
+	case "/debug_token":
+		s.handleDebugToken(w, r)
+		return
+	}
+
+	userID, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range segs {
+		if seg == "me" {
+			segs[i] = userID
+		}
+	}
+
+	if len(segs) == 1 {
+		switch segs[0] {
+		case "keyword_search":
+			s.handleKeywordSearch(w, r)
+		case "location_search":
+			s.handleLocationSearch(w, r)
+		case "profile_lookup":
+			s.handleProfileLookup(w, r)
+		case "profile_posts":
+			s.handleProfilePosts(w, r)
+		default:
+			s.handleObject(w, r, userID, segs[0])
+		}
+		return
+	}
+
+	if len(segs) == 2 {
+		id, edge := segs[0], segs[1]
+		switch {
+		case edge == "threads" && r.Method == http.MethodPost:
+			s.handleCreateContainer(w, r, userID, id)
+		case edge == "threads":
+			s.handleUserPosts(w, r, id)
+		case edge == "threads_publish":
+			s.handlePublish(w, r, userID, id)
+		case edge == "threads_publishing_limit":
+			s.handlePublishingLimit(w, r, userID, id)
+		case edge == "threads_insights":
+			s.handleAccountInsights(w, r, userID, id)
+		case edge == "insights":
+			s.handlePostInsights(w, r, id)
+		case edge == "mentions":
+			s.handleMentions(w, r, id)
+		case edge == "ghost_posts":
+			s.handleGhostPosts(w, r, id)
+		case edge == "replies" && s.users[id] != nil:
+			s.handleUserReplies(w, r, id)
+		case edge == "replies":
+			s.handlePostReplies(w, r, id)
+		case edge == "conversation":
+			s.handleConversation(w, r, id)
+		case edge == "pending_replies":
+			s.handlePendingReplies(w, r, userID, id)
+		case edge == "manage_reply":
+			s.handleManageReply(w, r, userID, id)
+		case edge == "manage_pending_reply":
+			s.handleManagePendingReply(w, r, userID, id)
+		case edge == "repost":
+			s.handleRepost(w, r, userID, id)
+		default:
+			writeNotFound(w)
+		}
+		return
+	}
+
+	writeNotFound(w)
+}
+
+// handleObject serves GET and DELETE on a bare node ID: containers, posts,
+// users and locations share one ID space.
+func (s *Server) handleObject(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if r.Method == http.MethodDelete {
+		s.handleDeletePost(w, r, userID, id)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeNotFound(w)
+		return
+	}
+
+	if c, ok := s.containers[id]; ok {
+		s.observeContainer(c)
+		writeJSON(w, http.StatusOK, threads.ContainerStatus{ID: c.id, Status: c.status, ErrorMessage: c.errorMessage})
+		return
+	}
+	if p, ok := s.posts[id]; ok {
+		view := s.view(p, userID)
+		writeJSON(w, http.StatusOK, &view)
+		return
+	}
+	if u, ok := s.users[id]; ok {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":                          u.id,
+			"username":                    u.username,
+			"name":                        u.name,
+			"threads_profile_picture_url": "",
+			"threads_biography":           u.biography,
+			"is_verified":                 u.verified,
+		})
+		return
+	}
+	if loc, ok := s.locations[id]; ok {
+		writeJSON(w, http.StatusOK, loc)
+		return
+	}
+	writeNotFound(w)
+}
+
+func (s *Server) takeFault(method, path string) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		if f.Path != "" {
+			if prefix, ok := strings.CutSuffix(f.Path, "*"); ok {
+				if !strings.HasPrefix(path, prefix) {
+					continue
+				}
+			} else if f.Path != path {
+				continue
+			}
+		}
+		f.Times--
+		if f.Times <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+// newID allocates a numeric node ID. Callers must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.FormatInt(s.nextID, 10)
+}
+
+func (s *Server) quotaLimit(quota Quota) int {
+	switch quota {
+	case QuotaPosts:
+		return s.quotas.Posts
+	case QuotaReplies:
+		return s.quotas.Replies
+	case QuotaDeletes:
+		return s.quotas.Deletes
+	case QuotaSearches:
+		return s.quotas.Searches
+	case QuotaLocationSearches:
+		return s.quotas.LocationSearches
+	default:
+		return 0
+	}
+}
+
+// usageLocked prunes expired usage and returns the count. Callers must hold s.mu.
+func (s *Server) usageLocked(quota Quota) int {
+	cutoff := s.now().Add(-s.quotas.Window)
+	uses := s.usage[quota]
+	kept := uses[:0]
+	for _, t := range uses {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	s.usage[quota] = kept
+	return len(kept)
+}
+
+// consumeQuota records one use of quota, or writes a 429 and returns false
+// when the quota is exhausted. Callers must hold s.mu.
+func (s *Server) consumeQuota(w http.ResponseWriter, quota Quota) bool {
+	if s.usageLocked(quota) >= s.quotaLimit(quota) {
+		writeGraphError(w, http.StatusTooManyRequests, ErrCodeRateLimit, 0,
+			fmt.Sprintf("Quota exceeded: %s limit of %d per %s reached", quota, s.quotaLimit(quota), s.quotas.Window), false)
+		return false
+	}
+	s.usage[quota] = append(s.usage[quota], s.now())
+	return true
+}
+
+func (s *Server) handlePublishingLimit(w http.ResponseWriter, r *http.Request, userID, id string) {
+	if id != userID {
+		writeGraphError(w, http.StatusForbidden, 10, 0, "Cannot read publishing limits for another user", false)
+		return
+	}
+	window := int(s.quotas.Window.Seconds())
+	limits := threads.PublishingLimits{
+		QuotaUsage:               s.usageLocked(QuotaPosts),
+		Config:                   threads.QuotaConfig{QuotaTotal: s.quotas.Posts, QuotaDuration: window},
+		ReplyQuotaUsage:          s.usageLocked(QuotaReplies),
+		ReplyConfig:              threads.QuotaConfig{QuotaTotal: s.quotas.Replies, QuotaDuration: window},
+		DeleteQuotaUsage:         s.usageLocked(QuotaDeletes),
+		DeleteConfig:             threads.QuotaConfig{QuotaTotal: s.quotas.Deletes, QuotaDuration: window},
+		LocationSearchQuotaUsage: s.usageLocked(QuotaLocationSearches),
+		LocationSearchConfig:     threads.QuotaConfig{QuotaTotal: s.quotas.LocationSearches, QuotaDuration: window},
+		SearchQuotaUsage:         s.usageLocked(QuotaSearches),
+		SearchConfig:             threads.QuotaConfig{QuotaTotal: s.quotas.Searches, QuotaDuration: window},
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": []threads.PublishingLimits{limits}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeGraphError(w http.ResponseWriter, status, code, subcode int, message string, transient bool) {
+	body := map[string]interface{}{
+		"message":      message,
+		"type":         "OAuthException",
+		"code":         code,
+		"is_transient": transient,
+		"fbtrace_id":   "threadstest",
+	}
+	if subcode != 0 {
+		body["error_subcode"] = subcode
+	}
+	writeJSON(w, status, map[string]interface{}{"error": body})
+}
+
+func writeError(w http.ResponseWriter, status, code, subcode int, message string) {
+	writeGraphError(w, status, code, subcode, message, false)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, ErrCodeInvalidParameter, 33, "Object does not exist, cannot be loaded due to missing permissions, or does not support this operation")
+}
+
+func writeSuccess(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]bool{"success": true})
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vals := range v {
+		out[k] = append([]string(nil), vals...)
+	}
+	return out
+}
@@ -0,0 +1,405 @@
+package threadstest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	threads "github.com/tirthpatell/threads-go"
+)
+
+func newTestServer(t *testing.T, opts *Options) (*Server, *threads.Client) {
+	t.Helper()
+	srv := NewServer(opts)
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return srv, client
+}
+
+func TestServer_TextPostLifecycle(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	ctx := context.Background()
+
+	post, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "hello world", TopicTag: "golang"})
+	if err != nil {
+		t.Fatalf("CreateTextPost: %v", err)
+	}
+	if post.Text != "hello world" || post.MediaType != threads.MediaTypeResponseText {
+		t.Errorf("unexpected post: %+v", post)
+	}
+	if post.Username != DefaultUsername {
+		t.Errorf("expected username %q, got %q", DefaultUsername, post.Username)
+	}
+
+	posts, err := client.GetUserPosts(ctx, threads.UserID(srv.UserID()), nil)
+	if err != nil {
+		t.Fatalf("GetUserPosts: %v", err)
+	}
+	if len(posts.Data) != 1 || posts.Data[0].ID != post.ID {
+		t.Fatalf("expected the new post to be listed, got %+v", posts.Data)
+	}
+
+	deletedID, err := client.DeletePost(ctx, threads.PostID(post.ID))
+	if err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+	if deletedID != post.ID {
+		t.Errorf("expected deleted ID %s, got %s", post.ID, deletedID)
+	}
+	if _, ok := srv.Post(post.ID); ok {
+		t.Error("expected post to be gone after delete")
+	}
+	if got := srv.Usage(QuotaDeletes); got != 1 {
+		t.Errorf("expected 1 delete recorded, got %d", got)
+	}
+}
+
+func TestServer_ContainerProcessing(t *testing.T) {
+	srv, client := newTestServer(t, &Options{
+		ProcessingPolls: 2,
+		ContainerOutcome: func(params url.Values) (string, string) {
+			if strings.Contains(params.Get("video_url"), "bad") {
+				return threads.ContainerStatusError, threads.ContainerErrInvalidAspectRatio
+			}
+			return threads.ContainerStatusFinished, ""
+		},
+	})
+	ctx := context.Background()
+
+	id, err := client.CreateMediaContainer(ctx, threads.MediaTypeVideo, "https://example.com/bad.mp4", "")
+	if err != nil {
+		t.Fatalf("CreateMediaContainer: %v", err)
+	}
+
+	want := []string{threads.ContainerStatusInProgress, threads.ContainerStatusInProgress, threads.ContainerStatusError}
+	for i, w := range want {
+		status, err := client.GetContainerStatus(ctx, id)
+		if err != nil {
+			t.Fatalf("GetContainerStatus: %v", err)
+		}
+		if status.Status != w {
+			t.Fatalf("poll %d: expected %s, got %s", i, w, status.Status)
+		}
+	}
+	status, _ := srv.ContainerStatus(id.String())
+	if status.ErrorMessage != threads.ContainerErrInvalidAspectRatio {
+		t.Errorf("expected error message %s, got %q", threads.ContainerErrInvalidAspectRatio, status.ErrorMessage)
+	}
+}
+
+func TestServer_PublishRequiresFinishedContainer(t *testing.T) {
+	srv, client := newTestServer(t, &Options{ProcessingPolls: 5})
+
+	id, err := client.CreateMediaContainer(context.Background(), threads.MediaTypeImage, "https://example.com/a.jpg", "")
+	if err != nil {
+		t.Fatalf("CreateMediaContainer: %v", err)
+	}
+
+	resp := postForm(t, srv, "/"+srv.UserID()+"/threads_publish", url.Values{"creation_id": {id.String()}})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 publishing an IN_PROGRESS container, got %d", resp.StatusCode)
+	}
+
+	if err := srv.ExpireContainer(id.String()); err != nil {
+		t.Fatal(err)
+	}
+	status, err := client.GetContainerStatus(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetContainerStatus: %v", err)
+	}
+	if status.Status != threads.ContainerStatusExpired {
+		t.Errorf("expected EXPIRED, got %s", status.Status)
+	}
+}
+
+func TestServer_ContainersExpireAfterLifetime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, client := newTestServer(t, &Options{ProcessingPolls: 100, Now: func() time.Time { return now }})
+
+	id, err := client.CreateMediaContainer(context.Background(), threads.MediaTypeImage, "https://example.com/a.jpg", "")
+	if err != nil {
+		t.Fatalf("CreateMediaContainer: %v", err)
+	}
+	now = now.Add(containerLifetime)
+
+	status, err := client.GetContainerStatus(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetContainerStatus: %v", err)
+	}
+	if status.Status != threads.ContainerStatusExpired {
+		t.Errorf("expected EXPIRED, got %s", status.Status)
+	}
+}
+
+func TestServer_EnforcesPublishingQuota(t *testing.T) {
+	srv, client := newTestServer(t, &Options{Quotas: Quotas{Posts: 2}})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "post"}); err != nil {
+			t.Fatalf("post %d: %v", i, err)
+		}
+	}
+
+	limits, err := client.GetPublishingLimits(ctx)
+	if err != nil {
+		t.Fatalf("GetPublishingLimits: %v", err)
+	}
+	if limits.QuotaUsage != 2 || limits.Config.QuotaTotal != 2 {
+		t.Errorf("unexpected limits: %+v", limits)
+	}
+
+	_, err = client.CreateTextPost(ctx, &threads.TextPostContent{Text: "one too many"})
+	if !threads.IsRateLimitError(err) {
+		t.Fatalf("expected rate limit error once quota is exhausted, got %v", err)
+	}
+	if srv.PostCount() != 2 {
+		t.Errorf("expected 2 stored posts, got %d", srv.PostCount())
+	}
+}
+
+func TestServer_InjectedFaultsAreRetried(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	path := "/" + srv.UserID() + "/threads"
+
+	srv.InjectFault(Fault{Method: http.MethodPost, Path: path, Status: http.StatusServiceUnavailable, Times: 2})
+
+	if _, err := client.CreateTextPost(context.Background(), &threads.TextPostContent{Text: "eventually"}); err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+	if got := srv.RequestCount(http.MethodPost, path); got != 3 {
+		t.Errorf("expected 3 container create attempts, got %d", got)
+	}
+}
+
+func TestServer_LostCreateResponseIsNotRetried(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	ctx := threads.WithIdempotencyKey(context.Background(), "lost-create")
+	path := "/" + srv.UserID() + "/threads"
+
+	// The container is created, but the client only sees a 500
+	f := ServerErrorFault(http.StatusInternalServerError)
+	f.Method, f.Path, f.Applied = http.MethodPost, path, true
+	srv.InjectFault(f)
+
+	if _, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "once"}); err == nil {
+		t.Fatal("expected the lost response to be reported")
+	}
+	if got := srv.RequestCount(http.MethodPost, path); got != 1 {
+		t.Errorf("expected 1 container create attempt, got %d", got)
+	}
+	if got := srv.ContainerCount(); got != 1 {
+		t.Errorf("expected 1 container, got %d", got)
+	}
+
+	// A retry by the caller publishes exactly one post
+	if _, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "once"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.PostCount(); got != 1 {
+		t.Errorf("expected 1 post, got %d", got)
+	}
+}
+
+func TestServer_InjectedRateLimitFault(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	f := RateLimitFault(0)
+	f.Path = "/" + srv.UserID()
+	f.Times = 10
+	srv.InjectFault(f)
+
+	_, err := client.GetMe(context.Background())
+	if !threads.IsRateLimitError(err) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+}
+
+func TestServer_RetryAfterRoundsUp(t *testing.T) {
+	srv, _ := newTestServer(t, nil)
+	f := RateLimitFault(200 * time.Millisecond)
+	f.Path = "/" + srv.UserID()
+	srv.InjectFault(f)
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, f.Path, nil))
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+}
+
+func TestServer_RevokedTokenIsRejected(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	srv.RevokeToken(srv.AccessToken())
+
+	_, err := client.GetMe(context.Background())
+	if !threads.IsAuthenticationError(err) {
+		t.Fatalf("expected authentication error, got %v", err)
+	}
+}
+
+func TestServer_RepliesModeration(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	ctx := context.Background()
+
+	post, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "approve me", EnableReplyApprovals: true})
+	if err != nil {
+		t.Fatalf("CreateTextPost: %v", err)
+	}
+
+	srv.AddUser("999", "someone")
+	replyID, err := srv.AddPost(PostSeed{AuthorID: "999", ReplyTo: post.ID, Text: "nice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replies, err := client.GetReplies(ctx, threads.PostID(post.ID), nil)
+	if err != nil {
+		t.Fatalf("GetReplies: %v", err)
+	}
+	if len(replies.Data) != 0 {
+		t.Fatalf("expected pending reply to be hidden from replies, got %d", len(replies.Data))
+	}
+
+	pending, err := client.GetPendingReplies(ctx, threads.PostID(post.ID), nil)
+	if err != nil {
+		t.Fatalf("GetPendingReplies: %v", err)
+	}
+	if len(pending.Data) != 1 || pending.Data[0].ID != replyID {
+		t.Fatalf("expected one pending reply, got %+v", pending.Data)
+	}
+
+	if err := client.ApprovePendingReply(ctx, threads.PostID(replyID)); err != nil {
+		t.Fatalf("ApprovePendingReply: %v", err)
+	}
+	if err := client.HideReply(ctx, threads.PostID(replyID)); err != nil {
+		t.Fatalf("HideReply: %v", err)
+	}
+
+	replies, err = client.GetReplies(ctx, threads.PostID(post.ID), nil)
+	if err != nil {
+		t.Fatalf("GetReplies: %v", err)
+	}
+	if len(replies.Data) != 1 || replies.Data[0].HideStatus != threads.HideStatusHidden {
+		t.Fatalf("expected one hidden reply, got %+v", replies.Data)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	for i := 0; i < 5; i++ {
+		if _, err := srv.AddPost(PostSeed{Text: "seeded"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var seen int
+	opts := &threads.PaginationOptions{Limit: 2}
+	for page := 0; page < 5; page++ {
+		resp, err := client.GetUserPosts(context.Background(), threads.UserID(srv.UserID()), opts)
+		if err != nil {
+			t.Fatalf("GetUserPosts: %v", err)
+		}
+		seen += len(resp.Data)
+		if resp.Paging.Cursors == nil || resp.Paging.Cursors.After == "" {
+			break
+		}
+		opts.After = resp.Paging.Cursors.After
+	}
+	if seen != 5 {
+		t.Errorf("expected to page through 5 posts, saw %d", seen)
+	}
+}
+
+func TestServer_SearchAndLocations(t *testing.T) {
+	srv, client := newTestServer(t, nil)
+	ctx := context.Background()
+
+	locID := srv.AddLocation(threads.Location{Name: "Golden Gate Park", Latitude: 37.77, Longitude: -122.45})
+	if _, err := client.CreateTextPost(ctx, &threads.TextPostContent{Text: "picnic in the park", LocationID: locID}); err != nil {
+		t.Fatalf("CreateTextPost: %v", err)
+	}
+
+	results, err := client.KeywordSearch(ctx, "picnic", nil)
+	if err != nil {
+		t.Fatalf("KeywordSearch: %v", err)
+	}
+	if len(results.Data) != 1 || results.Data[0].Location == nil || results.Data[0].Location.ID != locID {
+		t.Fatalf("expected one located search hit, got %+v", results.Data)
+	}
+
+	locations, err := client.SearchLocations(ctx, "golden", nil, nil)
+	if err != nil {
+		t.Fatalf("SearchLocations: %v", err)
+	}
+	if len(locations.Data) != 1 || locations.Data[0].ID != locID {
+		t.Fatalf("expected location hit, got %+v", locations.Data)
+	}
+	if srv.Usage(QuotaSearches) != 1 || srv.Usage(QuotaLocationSearches) != 1 {
+		t.Error("expected search quotas to be consumed")
+	}
+}
+
+func TestServer_TokenFlow(t *testing.T) {
+	srv := NewServer(nil)
+	defer srv.Close()
+	srv.AddAuthorizationCode("good-code", "4242")
+
+	client, err := threads.NewClient(srv.Config())
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.ExchangeCodeForToken(ctx, "good-code", "state", "state"); err != nil {
+		t.Fatalf("ExchangeCodeForToken: %v", err)
+	}
+	short := client.GetAccessToken()
+
+	if err := client.RefreshToken(ctx); err == nil {
+		t.Error("expected refreshing a short-lived token to fail")
+	}
+
+	if err := client.GetLongLivedToken(ctx); err != nil {
+		t.Fatalf("GetLongLivedToken: %v", err)
+	}
+	long := client.GetAccessToken()
+	if long == short {
+		t.Error("expected a new long-lived token")
+	}
+
+	if err := client.RefreshToken(ctx); err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	debug, err := client.DebugToken(ctx, "")
+	if err != nil {
+		t.Fatalf("DebugToken: %v", err)
+	}
+	if !debug.Data.IsValid || debug.Data.UserID != "4242" {
+		t.Errorf("unexpected debug info: %+v", debug.Data)
+	}
+}
+
+func postForm(t *testing.T, srv *Server, path string, form url.Values) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+srv.AccessToken())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	return resp
+}
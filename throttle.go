@@ -0,0 +1,195 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointClass groups API calls that share a publishing quota.
+type EndpointClass string
+
+// Endpoint classes with separate quotas in PublishingLimits
+const (
+	EndpointPublish        EndpointClass = "publish"         // Post containers (Config)
+	EndpointReply          EndpointClass = "reply"           // Reply containers (ReplyConfig)
+	EndpointDelete         EndpointClass = "delete"          // Post deletion (DeleteConfig)
+	EndpointSearch         EndpointClass = "search"          // Keyword search (SearchConfig)
+	EndpointLocationSearch EndpointClass = "location_search" // Location search (LocationSearchConfig)
+)
+
+var endpointClasses = []EndpointClass{EndpointPublish, EndpointReply, EndpointDelete, EndpointSearch, EndpointLocationSearch}
+
+// tokenBucket paces one endpoint class. Tokens refill continuously at
+// capacity per window, so a drained bucket allows one call every
+// window/capacity.
+type tokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second
+	last     time.Time
+}
+
+func newTokenBucket(quota QuotaConfig, used int, now time.Time) *tokenBucket {
+	capacity := float64(quota.QuotaTotal)
+	tokens := capacity - float64(used)
+	if tokens < 0 {
+		tokens = 0
+	}
+	return &tokenBucket{
+		capacity: capacity,
+		tokens:   tokens,
+		rate:     capacity / float64(quota.QuotaDuration),
+		last:     now,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it. Tokens may go negative so that concurrent callers queue up.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve whose call was abandoned.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// SetEndpointLimits enables proactive throttling: calls in each endpoint
+// class are paced by a token bucket holding that class's quota, refilled
+// evenly over the quota duration and starting from the remaining quota in
+// limits. Classes with no quota in limits are not throttled. Passing nil
+// disables proactive throttling.
+func (rl *RateLimiter) SetEndpointLimits(limits *PublishingLimits) {
+	var buckets map[EndpointClass]*tokenBucket
+	if limits != nil {
+		now := time.Now()
+		buckets = make(map[EndpointClass]*tokenBucket)
+		for _, class := range endpointClasses {
+			quota, used := quotaFor(limits, class)
+			if quota.QuotaTotal > 0 && quota.QuotaDuration > 0 {
+				buckets[class] = newTokenBucket(quota, used, now)
+			}
+		}
+	}
+
+	rl.mu.Lock()
+	rl.buckets = buckets
+	rl.mu.Unlock()
+}
+
+// WaitForEndpoint blocks until a call in class may proceed under proactive
+// throttling. It returns immediately when proactive throttling is disabled
+// or class has no quota.
+func (rl *RateLimiter) WaitForEndpoint(ctx context.Context, class EndpointClass) error {
+	rl.mu.RLock()
+	bucket := rl.buckets[class]
+	rl.mu.RUnlock()
+	if bucket == nil {
+		return nil
+	}
+
+	wait := bucket.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	if rl.logger != nil {
+		rl.logger.Debug("Proactively pacing request",
+			"endpoint_class", string(class),
+			"wait_duration", wait.String(),
+		)
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		bucket.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// EnableProactiveRateLimiting fetches the account's publishing limits and
+// paces subsequent publish, reply, delete, search and location search calls
+// so they stay within those quotas instead of waiting for a 429. Call it
+// again to resynchronise with server-side usage, for example before a bulk
+// job. It has no effect while rate limiting is disabled.
+func (c *Client) EnableProactiveRateLimiting(ctx context.Context) error {
+	limits, err := c.GetPublishingLimits(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get publishing limits: %w", err)
+	}
+
+	c.mu.RLock()
+	rl := c.rateLimiter
+	c.mu.RUnlock()
+	if rl != nil {
+		rl.SetEndpointLimits(limits)
+	}
+	return nil
+}
+
+// DisableProactiveRateLimiting stops pacing calls by endpoint class.
+func (c *Client) DisableProactiveRateLimiting() {
+	c.mu.RLock()
+	rl := c.rateLimiter
+	c.mu.RUnlock()
+	if rl != nil {
+		rl.SetEndpointLimits(nil)
+	}
+}
+
+// classifyEndpoint returns the quota class of a request, or "" if the
+// request does not count against a publishing quota.
+func classifyEndpoint(opts *RequestOptions) EndpointClass {
+	path := opts.Path
+	switch opts.Method {
+	case "POST":
+		if !strings.HasSuffix(path, "/threads") {
+			return ""
+		}
+		// Carousel items are published as part of their parent post.
+		params, _ := opts.Body.(url.Values)
+		if params.Get("is_carousel_item") == "true" {
+			return ""
+		}
+		if params.Get("reply_to_id") != "" {
+			return EndpointReply
+		}
+		return EndpointPublish
+	case "DELETE":
+		return EndpointDelete
+	case "GET":
+		switch {
+		case strings.HasSuffix(path, "/keyword_search"):
+			return EndpointSearch
+		case strings.HasSuffix(path, "/location_search"):
+			return EndpointLocationSearch
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_PacesAfterQuotaIsUsed(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400}, 248, now)
+
+	for i := 0; i < 2; i++ {
+		if wait := bucket.reserve(now); wait != 0 {
+			t.Fatalf("call %d: expected remaining quota to be used without waiting, got %s", i, wait)
+		}
+	}
+
+	interval := time.Duration(86400.0 / 250 * float64(time.Second))
+	if wait := bucket.reserve(now); wait < interval-time.Second || wait > interval+time.Second {
+		t.Errorf("expected to wait about %s for the next token, got %s", interval, wait)
+	}
+	if wait := bucket.reserve(now); wait < 2*interval-time.Second {
+		t.Errorf("expected queued callers to wait successively longer, got %s", wait)
+	}
+
+	bucket.cancel()
+	bucket.cancel()
+	if wait := bucket.reserve(now.Add(interval)); wait != 0 {
+		t.Errorf("expected a refilled token after one interval, got %s", wait)
+	}
+}
+
+func TestClassifyEndpoint(t *testing.T) {
+	tests := []struct {
+		opts *RequestOptions
+		want EndpointClass
+	}{
+		{&RequestOptions{Method: "POST", Path: "/123/threads", Body: url.Values{"text": {"hi"}}}, EndpointPublish},
+		{&RequestOptions{Method: "POST", Path: "/123/threads", Body: url.Values{"reply_to_id": {"9"}}}, EndpointReply},
+		{&RequestOptions{Method: "POST", Path: "/123/threads", Body: url.Values{"is_carousel_item": {"true"}}}, ""},
+		{&RequestOptions{Method: "POST", Path: "/123/threads_publish"}, ""},
+		{&RequestOptions{Method: "DELETE", Path: "/456"}, EndpointDelete},
+		{&RequestOptions{Method: "GET", Path: "/keyword_search"}, EndpointSearch},
+		{&RequestOptions{Method: "GET", Path: "/location_search"}, EndpointLocationSearch},
+		{&RequestOptions{Method: "GET", Path: "/me"}, ""},
+	}
+	for _, tt := range tests {
+		if got := classifyEndpoint(tt.opts); got != tt.want {
+			t.Errorf("%s %s: expected %q, got %q", tt.opts.Method, tt.opts.Path, tt.want, got)
+		}
+	}
+}
+
+func TestClient_ProactiveRateLimiting(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/threads_publishing_limit"):
+			// Search quota exhausted; refills at 10 per second.
+			_, _ = w.Write([]byte(`{"data":[{"search_quota_usage":10,"search_config":{"quota_total":10,"quota_duration":1}}]}`))
+		case r.URL.Path == "/me":
+			_, _ = w.Write([]byte(`{"id":"12345","username":"tester"}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}
+	client := testClient(t, http.HandlerFunc(handler))
+
+	if err := client.EnableProactiveRateLimiting(context.Background()); err != nil {
+		t.Fatalf("EnableProactiveRateLimiting: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := client.GetMe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected calls without a quota not to be paced, took %s", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := client.KeywordSearch(context.Background(), "golang", nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected search to wait for a token, took %s", elapsed)
+	}
+
+	client.DisableProactiveRateLimiting()
+	start = time.Now()
+	if _, err := client.KeywordSearch(context.Background(), "golang", nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected no pacing once disabled, took %s", elapsed)
+	}
+}
+
+func TestRateLimiter_WaitForEndpointHonorsContext(t *testing.T) {
+	rl := NewRateLimiter(&RateLimiterConfig{})
+	rl.SetEndpointLimits(&PublishingLimits{QuotaUsage: 250, Config: QuotaConfig{QuotaTotal: 250, QuotaDuration: 86400}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rl.WaitForEndpoint(ctx, EndpointPublish); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if err := rl.WaitForEndpoint(context.Background(), EndpointDelete); err != nil {
+		t.Errorf("expected classes without a quota to pass, got %v", err)
+	}
+}
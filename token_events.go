@@ -0,0 +1,91 @@
+package threads
+
+import (
+	"errors"
+	"time"
+)
+
+// TokenEventType identifies what happened to the client's access token.
+type TokenEventType string
+
+// Token lifecycle event types delivered to Config.OnTokenEvent
+const (
+	TokenEventSet         TokenEventType = "set"         // SetTokenInfo replaced the token
+	TokenEventExchanged   TokenEventType = "exchanged"   // ExchangeCodeForToken obtained a short-lived token
+	TokenEventLongLived   TokenEventType = "long_lived"  // GetLongLivedToken upgraded the token
+	TokenEventRefreshed   TokenEventType = "refreshed"   // RefreshToken extended the token
+	TokenEventCleared     TokenEventType = "cleared"     // ClearToken removed the token
+	TokenEventInvalidated TokenEventType = "invalidated" // The API rejected the token (error code 190)
+)
+
+// TokenEvent describes a change to the client's access token. OldToken and
+// NewToken are copies with AccessToken redacted, so events are safe to log;
+// either may be nil (no previous token, or the token was cleared).
+type TokenEvent struct {
+	Type     TokenEventType
+	Reason   string
+	OldToken *TokenInfo
+	NewToken *TokenInfo
+	Time     time.Time
+
+	// Err is the API error that invalidated the token, set only for
+	// TokenEventInvalidated.
+	Err error
+}
+
+// redactTokenInfo returns a copy of token with the access token replaced.
+func redactTokenInfo(token *TokenInfo) *TokenInfo {
+	if token == nil {
+		return nil
+	}
+	redacted := *token
+	if redacted.AccessToken != "" {
+		redacted.AccessToken = "[REDACTED]"
+	}
+	return &redacted
+}
+
+// emitTokenEvent delivers an event to Config.OnTokenEvent. It must be called
+// without holding c.mu so the callback may use the client.
+func (c *Client) emitTokenEvent(eventType TokenEventType, reason string, oldToken, newToken *TokenInfo, err error) {
+	if c.config.OnTokenEvent == nil {
+		return
+	}
+	c.config.OnTokenEvent(TokenEvent{
+		Type:     eventType,
+		Reason:   reason,
+		OldToken: redactTokenInfo(oldToken),
+		NewToken: redactTokenInfo(newToken),
+		Time:     time.Now(),
+		Err:      err,
+	})
+}
+
+// handleInvalidToken is called by the HTTP client when a request made with
+// accessToken fails with ErrorCodeInvalidOAuthToken. Requests made with a
+// token other than the client's current one (for example DebugToken on an
+// arbitrary token) are ignored.
+func (c *Client) handleInvalidToken(accessToken string, err error) {
+	if accessToken == "" {
+		return
+	}
+	c.mu.RLock()
+	current := c.tokenInfo
+	matches := current != nil && c.accessToken == accessToken
+	c.mu.RUnlock()
+	if !matches {
+		return
+	}
+
+	if c.config.Logger != nil {
+		c.config.Logger.Warn("Access token rejected by the API", "user_id", current.UserID, "error", err.Error())
+	}
+	c.emitTokenEvent(TokenEventInvalidated, err.Error(), current, nil, err)
+}
+
+// isInvalidTokenError reports whether err is an AuthenticationError carrying
+// ErrorCodeInvalidOAuthToken.
+func isInvalidTokenError(err error) bool {
+	var authErr *AuthenticationError
+	return errors.As(err, &authErr) && authErr.Code == ErrorCodeInvalidOAuthToken
+}
@@ -0,0 +1,117 @@
+package threads
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+type tokenEventRecorder struct {
+	mu     sync.Mutex
+	events []TokenEvent
+}
+
+func (r *tokenEventRecorder) record(event TokenEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *tokenEventRecorder) types() []TokenEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types := make([]TokenEventType, len(r.events))
+	for i, e := range r.events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func (r *tokenEventRecorder) last() TokenEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[len(r.events)-1]
+}
+
+func tokenEventClient(t *testing.T, handler http.Handler) (*Client, *tokenEventRecorder) {
+	t.Helper()
+	recorder := &tokenEventRecorder{}
+	config := testClientConfig(t, handler)
+	config.OnTokenEvent = recorder.record
+	client := testClientWithConfig(t, config)
+	recorder.events = nil // drop the event from the helper's initial token
+	return client, recorder
+}
+
+func TestTokenEvents_SetRefreshClear(t *testing.T) {
+	handler := jsonHandler(200, `{"access_token":"new-token","token_type":"bearer","expires_in":5184000}`)
+	client, recorder := tokenEventClient(t, handler)
+
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RefreshToken(context.Background()); err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	refreshed := recorder.last()
+	if refreshed.Type != TokenEventRefreshed || refreshed.Reason == "" {
+		t.Errorf("expected refreshed event with a reason, got %+v", refreshed)
+	}
+	if refreshed.OldToken == nil || refreshed.NewToken == nil {
+		t.Fatal("expected old and new tokens on refresh event")
+	}
+	if refreshed.OldToken.AccessToken != "[REDACTED]" || refreshed.NewToken.AccessToken != "[REDACTED]" {
+		t.Errorf("expected access tokens to be redacted, got %q and %q",
+			refreshed.OldToken.AccessToken, refreshed.NewToken.AccessToken)
+	}
+	if refreshed.OldToken.UserID != "12345" || !refreshed.NewToken.ExpiresAt.After(refreshed.OldToken.ExpiresAt) {
+		t.Errorf("expected token metadata to be preserved, got old=%+v new=%+v", refreshed.OldToken, refreshed.NewToken)
+	}
+	if client.GetAccessToken() != "new-token" {
+		t.Error("redaction must not affect the client's own token")
+	}
+
+	if err := client.ClearToken(); err != nil {
+		t.Fatal(err)
+	}
+	cleared := recorder.last()
+	if cleared.OldToken == nil || cleared.NewToken != nil {
+		t.Errorf("expected cleared event with only an old token, got %+v", cleared)
+	}
+
+	want := []TokenEventType{TokenEventSet, TokenEventRefreshed, TokenEventCleared}
+	got := recorder.types()
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestTokenEvents_InvalidatedOnErrorCode190(t *testing.T) {
+	for _, status := range []int{400, 401} {
+		handler := jsonHandler(status, `{"error":{"message":"Error validating access token","type":"OAuthException","code":190}}`)
+		client, recorder := tokenEventClient(t, handler)
+		if err := client.SetTokenInfo(refreshableToken()); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := client.GetMe(context.Background())
+		if !IsAuthenticationError(err) {
+			t.Errorf("status %d: expected authentication error, got %v", status, err)
+		}
+
+		event := recorder.last()
+		if event.Type != TokenEventInvalidated {
+			t.Fatalf("status %d: expected invalidated event, got %v", status, recorder.types())
+		}
+		if event.Err == nil || event.OldToken == nil || event.OldToken.AccessToken != "[REDACTED]" {
+			t.Errorf("status %d: unexpected invalidated event %+v", status, event)
+		}
+	}
+}
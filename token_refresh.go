@@ -0,0 +1,170 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// AutoRefreshOptions configures the background token refresher started by
+// Client.StartAutoRefresh. Zero values use the DefaultAutoRefresh* constants.
+type AutoRefreshOptions struct {
+	// RefreshBefore is how long before expiry the token is refreshed.
+	RefreshBefore time.Duration
+
+	// CheckInterval caps the time between expiry checks, so tokens replaced
+	// via SetTokenInfo are picked up promptly.
+	CheckInterval time.Duration
+
+	// RetryInterval is the delay before retrying after a failed refresh.
+	RetryInterval time.Duration
+
+	// OnRefresh is called with the new token after each successful refresh.
+	// Callbacks run on the refresher goroutine and may call StopAutoRefresh.
+	OnRefresh func(token *TokenInfo)
+
+	// OnError is called with the error after each failed refresh.
+	OnError func(err error)
+}
+
+type autoRefresher struct {
+	cancel     context.CancelFunc
+	done       chan struct{}
+	inCallback atomic.Bool // OnRefresh or OnError is running
+}
+
+// StartAutoRefresh starts a background goroutine that refreshes the
+// long-lived access token before it expires. Refreshed tokens are persisted
+// through the configured TokenStorage. The refresher runs until ctx is
+// cancelled or StopAutoRefresh is called.
+//
+// The Threads API only refreshes long-lived tokens that are at least
+// MinTokenAgeForRefresh old and not yet expired; refreshes are scheduled
+// accordingly. It returns an error if a refresher is already running.
+func (c *Client) StartAutoRefresh(ctx context.Context, opts *AutoRefreshOptions) error {
+	o := AutoRefreshOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.RefreshBefore <= 0 {
+		o.RefreshBefore = DefaultAutoRefreshBefore
+	}
+	if o.CheckInterval <= 0 {
+		o.CheckInterval = DefaultAutoRefreshCheckInterval
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = DefaultAutoRefreshRetryInterval
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if c.autoRefresh != nil {
+		return fmt.Errorf("auto refresh is already running")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	ar := &autoRefresher{cancel: cancel, done: make(chan struct{})}
+	c.autoRefresh = ar
+
+	go c.runAutoRefresh(ctx, &o, ar)
+
+	if c.config.Logger != nil {
+		c.config.Logger.Info("Started automatic token refresh",
+			"refresh_before", o.RefreshBefore,
+			"check_interval", o.CheckInterval)
+	}
+	return nil
+}
+
+// StopAutoRefresh stops the background refresher, if running, and waits
+// for it to exit. If an OnRefresh or OnError callback is running, for
+// example because it called StopAutoRefresh, it does not wait for the
+// callback to return; no further refresh is started either way.
+func (c *Client) StopAutoRefresh() {
+	c.refreshMu.Lock()
+	ar := c.autoRefresh
+	c.autoRefresh = nil
+	c.refreshMu.Unlock()
+
+	if ar == nil {
+		return
+	}
+	ar.cancel()
+	if !ar.inCallback.Load() {
+		<-ar.done
+	}
+}
+
+func (c *Client) runAutoRefresh(ctx context.Context, opts *AutoRefreshOptions, ar *autoRefresher) {
+	defer func() {
+		c.refreshMu.Lock()
+		if c.autoRefresh == ar {
+			c.autoRefresh = nil
+		}
+		c.refreshMu.Unlock()
+		close(ar.done)
+	}()
+
+	for {
+		wait := c.nextAutoRefresh(opts)
+		if wait <= 0 {
+			err := c.RefreshToken(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err == nil {
+				token := c.GetTokenInfo()
+				if c.config.Logger != nil {
+					c.config.Logger.Info("Automatically refreshed access token", "expires_at", token.ExpiresAt)
+				}
+				if opts.OnRefresh != nil {
+					ar.inCallback.Store(true)
+					opts.OnRefresh(token)
+					ar.inCallback.Store(false)
+				}
+				continue
+			}
+
+			if c.config.Logger != nil {
+				c.config.Logger.Warn("Automatic token refresh failed",
+					"error", err.Error(),
+					"retry_in", opts.RetryInterval)
+			}
+			if opts.OnError != nil {
+				ar.inCallback.Store(true)
+				opts.OnError(err)
+				ar.inCallback.Store(false)
+			}
+			wait = opts.RetryInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// nextAutoRefresh returns how long to wait before the next refresh attempt,
+// capped at opts.CheckInterval. A non-positive result means refresh now.
+func (c *Client) nextAutoRefresh(opts *AutoRefreshOptions) time.Duration {
+	token := c.GetTokenInfo()
+	if token == nil || token.AccessToken == "" {
+		return opts.CheckInterval
+	}
+
+	due := token.ExpiresAt.Add(-opts.RefreshBefore)
+	if earliest := token.CreatedAt.Add(MinTokenAgeForRefresh); earliest.After(due) {
+		due = earliest
+	}
+
+	wait := time.Until(due)
+	if wait > opts.CheckInterval {
+		return opts.CheckInterval
+	}
+	return wait
+}
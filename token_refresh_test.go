@@ -0,0 +1,219 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// refreshableToken returns a long-lived token that is old enough to refresh
+// and due within the default RefreshBefore window.
+func refreshableToken() *TokenInfo {
+	return &TokenInfo{
+		AccessToken: "old-token",
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+		UserID:      "12345",
+		CreatedAt:   time.Now().Add(-48 * time.Hour),
+	}
+}
+
+func TestRefreshToken_SingleFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new-token","token_type":"bearer","expires_in":5184000}`))
+	}
+	client := testClientWithConfig(t, testClientConfig(t, http.HandlerFunc(handler)))
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- client.RefreshToken(context.Background())
+		}()
+	}
+
+	// Give every goroutine a chance to join the in-flight refresh.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected one refresh request, got %d", got)
+	}
+	if client.GetAccessToken() != "new-token" {
+		t.Errorf("expected refreshed token, got %q", client.GetAccessToken())
+	}
+}
+
+func TestRefreshToken_LeaderCancellationIsNotShared(t *testing.T) {
+	release := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new-token","token_type":"bearer","expires_in":5184000}`))
+	}
+	client := testClientWithConfig(t, testClientConfig(t, http.HandlerFunc(handler)))
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() { leader <- client.RefreshToken(leaderCtx) }()
+	time.Sleep(20 * time.Millisecond)
+
+	follower := make(chan error, 1)
+	go func() { follower <- client.RefreshToken(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to see its cancellation, got %v", err)
+	}
+	close(release)
+	if err := <-follower; err != nil {
+		t.Errorf("expected the follower to get the refresh result, got %v", err)
+	}
+	if client.GetAccessToken() != "new-token" {
+		t.Errorf("expected refreshed token, got %q", client.GetAccessToken())
+	}
+}
+
+func TestStartAutoRefresh_StopFromCallback(t *testing.T) {
+	handler := jsonHandler(200, `{"access_token":"new-token","token_type":"bearer","expires_in":5184000}`)
+	client := testClientWithConfig(t, testClientConfig(t, handler))
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan struct{})
+	err := client.StartAutoRefresh(context.Background(), &AutoRefreshOptions{
+		OnRefresh: func(*TokenInfo) {
+			client.StopAutoRefresh()
+			close(stopped)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("StopAutoRefresh deadlocked when called from OnRefresh")
+	}
+}
+
+func TestStartAutoRefresh_RefreshesAndPersists(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "old-token" {
+			t.Errorf("expected refresh with the current token, got %q", r.URL.Query().Get("access_token"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new-token","token_type":"bearer","expires_in":5184000}`))
+	}
+	storage := &MemoryTokenStorage{}
+	config := testClientConfig(t, http.HandlerFunc(handler))
+	config.TokenStorage = storage
+	client := testClientWithConfig(t, config)
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed := make(chan *TokenInfo, 1)
+	err := client.StartAutoRefresh(context.Background(), &AutoRefreshOptions{
+		OnRefresh: func(token *TokenInfo) { refreshed <- token },
+		OnError:   func(err error) { t.Errorf("unexpected refresh error: %v", err) },
+	})
+	if err != nil {
+		t.Fatalf("StartAutoRefresh: %v", err)
+	}
+	defer client.StopAutoRefresh()
+
+	if err := client.StartAutoRefresh(context.Background(), nil); err == nil {
+		t.Error("expected starting a second refresher to fail")
+	}
+
+	select {
+	case token := <-refreshed:
+		if token.AccessToken != "new-token" {
+			t.Errorf("expected new token in callback, got %q", token.AccessToken)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for automatic refresh")
+	}
+
+	stored, err := storage.Load()
+	if err != nil || stored.AccessToken != "new-token" {
+		t.Errorf("expected refreshed token to be persisted, got %+v (%v)", stored, err)
+	}
+}
+
+func TestStartAutoRefresh_ReportsFailures(t *testing.T) {
+	handler := jsonHandler(400, `{"error":{"message":"Invalid token","type":"OAuthException","code":190}}`)
+	client := testClientWithConfig(t, testClientConfig(t, handler))
+	if err := client.SetTokenInfo(refreshableToken()); err != nil {
+		t.Fatal(err)
+	}
+
+	failures := make(chan error, 10)
+	err := client.StartAutoRefresh(context.Background(), &AutoRefreshOptions{
+		RetryInterval: 10 * time.Millisecond,
+		OnError:       func(err error) { failures <- err },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-failures:
+			if err == nil {
+				t.Fatal("expected a non-nil error")
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for refresh failure")
+		}
+	}
+
+	client.StopAutoRefresh()
+	if err := client.StartAutoRefresh(context.Background(), nil); err != nil {
+		t.Errorf("expected refresher to be restartable after stop, got %v", err)
+	}
+	client.StopAutoRefresh()
+}
+
+func TestNextAutoRefresh_WaitsForMinimumTokenAge(t *testing.T) {
+	client := testClientWithConfig(t, testClientConfig(t, http.NotFoundHandler()))
+	token := refreshableToken()
+	token.CreatedAt = time.Now()
+	if err := client.SetTokenInfo(token); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &AutoRefreshOptions{RefreshBefore: DefaultAutoRefreshBefore, CheckInterval: 48 * time.Hour}
+	wait := client.nextAutoRefresh(opts)
+	if wait < 23*time.Hour || wait > MinTokenAgeForRefresh {
+		t.Errorf("expected to wait roughly %s for a fresh token, got %s", MinTokenAgeForRefresh, wait)
+	}
+}
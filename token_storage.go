@@ -0,0 +1,237 @@
+package threads
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// tokenFileKeyContext binds keys derived for FileTokenStorage to this purpose
+// so the same secret used elsewhere yields a different key.
+const tokenFileKeyContext = "threads-go/FileTokenStorage/v1"
+
+// encryptedTokenFile is the on-disk envelope written by an encrypted FileTokenStorage.
+type encryptedTokenFile struct {
+	Version    int    `json:"version"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// FileTokenStorage persists the token as a JSON file so that it survives
+// restarts. Writes are atomic (temp file + rename), the file is created with
+// 0600 permissions, and access is serialized across processes with an
+// advisory lock on a sidecar "<path>.lock" file.
+//
+// Use NewEncryptedFileTokenStorage to encrypt the file at rest.
+type FileTokenStorage struct {
+	path string
+	aead cipher.AEAD // nil when the file is stored in plaintext
+	mu   sync.Mutex
+}
+
+// NewFileTokenStorage creates a storage backed by the file at path.
+// The parent directory is created with 0700 permissions if it does not exist.
+func NewFileTokenStorage(path string) (*FileTokenStorage, error) {
+	if path == "" {
+		return nil, NewValidationError(400, "Token file path is required", "FileTokenStorage needs a non-empty path", "path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create token directory: %w", err)
+	}
+	return &FileTokenStorage{path: path}, nil
+}
+
+// NewEncryptedFileTokenStorage creates a storage that encrypts the token file
+// with AES-256-GCM. The key is derived from secret, which should be a
+// high-entropy value (for example 32 random bytes from a secrets manager)
+// rather than a human-chosen password.
+func NewEncryptedFileTokenStorage(path string, secret []byte) (*FileTokenStorage, error) {
+	if len(secret) < 16 {
+		return nil, NewValidationError(400, "Encryption secret too short", "Secret must be at least 16 bytes", "secret")
+	}
+	s, err := NewFileTokenStorage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(tokenFileKeyContext))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise token cipher: %w", err)
+	}
+	s.aead, err = cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise token cipher: %w", err)
+	}
+	return s, nil
+}
+
+// Path returns the token file path.
+func (s *FileTokenStorage) Path() string {
+	return s.path
+}
+
+// Store atomically writes the token to disk.
+func (s *FileTokenStorage) Store(token *TokenInfo) error {
+	if token == nil {
+		return NewValidationError(400, "Token is required", "Cannot store a nil token", "token")
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode token: %w", err)
+	}
+	if s.aead != nil {
+		if data, err = s.seal(data); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return writeFileAtomic(s.path, data, 0o600)
+}
+
+// Load reads the token from disk. It returns an AuthenticationError if no
+// token has been stored yet.
+func (s *FileTokenStorage) Load() (*TokenInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path)
+	unlock()
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, NewAuthenticationError(401, "No token stored", fmt.Sprintf("Token file %s does not exist", s.path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	if s.aead != nil {
+		if data, err = s.open(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var token TokenInfo
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode token file: %w", err)
+	}
+	return &token, nil
+}
+
+// Delete removes the token file. Deleting a missing file is not an error.
+func (s *FileTokenStorage) Delete() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete token file: %w", err)
+	}
+	return nil
+}
+
+// lock takes the cross-process lock. The lock lives on a sidecar file
+// because the token file itself is replaced on every Store.
+func (s *FileTokenStorage) lock(exclusive bool) (func(), error) {
+	f, err := os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open token lock file: %w", err)
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock token file: %w", err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
+
+func (s *FileTokenStorage) seal(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return json.Marshal(encryptedTokenFile{
+		Version:    1,
+		Nonce:      nonce,
+		Ciphertext: s.aead.Seal(nil, nonce, plaintext, []byte(tokenFileKeyContext)),
+	})
+}
+
+func (s *FileTokenStorage) open(data []byte) ([]byte, error) {
+	var env encryptedTokenFile
+	if err := json.Unmarshal(data, &env); err != nil || env.Version != 1 || len(env.Nonce) != s.aead.NonceSize() {
+		return nil, fmt.Errorf("token file %s is not an encrypted token file", s.path)
+	}
+	plaintext, err := s.aead.Open(nil, env.Nonce, env.Ciphertext, []byte(tokenFileKeyContext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token file (wrong secret or corrupted file): %w", err)
+	}
+	return plaintext, nil
+}
+
+// writeFileAtomic writes data to a temp file in the target directory, syncs
+// it and renames it over path so readers never observe a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	cleanup := func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		cleanup()
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
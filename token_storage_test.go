@@ -0,0 +1,173 @@
+package threads
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testTokenInfo() *TokenInfo {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &TokenInfo{
+		AccessToken: "secret-access-token",
+		TokenType:   "Bearer",
+		ExpiresAt:   now.Add(time.Hour),
+		UserID:      "12345",
+		CreatedAt:   now,
+	}
+}
+
+func TestFileTokenStorage_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "token.json")
+	storage, err := NewFileTokenStorage(path)
+	if err != nil {
+		t.Fatalf("NewFileTokenStorage: %v", err)
+	}
+
+	if _, err := storage.Load(); !IsAuthenticationError(err) {
+		t.Fatalf("expected authentication error before any Store, got %v", err)
+	}
+
+	want := testTokenInfo()
+	if err := storage.Store(want); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	got, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.AccessToken != want.AccessToken || got.UserID != want.UserID || !got.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Errorf("expected 0600 permissions, got %o", perm)
+		}
+	}
+
+	if err := storage.Delete(); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := storage.Delete(); err != nil {
+		t.Errorf("expected deleting a missing token to succeed, got %v", err)
+	}
+	if _, err := storage.Load(); !IsAuthenticationError(err) {
+		t.Errorf("expected authentication error after Delete, got %v", err)
+	}
+}
+
+func TestFileTokenStorage_LeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewFileTokenStorage(filepath.Join(dir, "token.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := storage.Store(testTokenInfo()); err != nil {
+				t.Errorf("Store: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := storage.Load(); err != nil {
+		t.Fatalf("expected a valid token after concurrent writes, got %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Name() != "token.json" && e.Name() != "token.json.lock" {
+			t.Errorf("unexpected leftover file %s", e.Name())
+		}
+	}
+}
+
+func TestEncryptedFileTokenStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.enc")
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	storage, err := NewEncryptedFileTokenStorage(path, secret)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileTokenStorage: %v", err)
+	}
+	if err := storage.Store(testTokenInfo()); err != nil {
+		t.Fatalf("Store: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("secret-access-token")) {
+		t.Fatal("expected the token to be encrypted at rest")
+	}
+
+	got, err := storage.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.AccessToken != "secret-access-token" {
+		t.Errorf("expected decrypted token, got %q", got.AccessToken)
+	}
+
+	wrong, err := NewEncryptedFileTokenStorage(path, []byte("another-secret-of-enough-length"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := wrong.Load(); err == nil {
+		t.Error("expected Load with the wrong secret to fail")
+	}
+
+	plain, err := NewFileTokenStorage(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := plain.Load(); err == nil && tok.AccessToken != "" {
+		t.Error("expected plaintext storage not to read an encrypted token")
+	}
+}
+
+func TestEncryptedFileTokenStorage_RejectsShortSecret(t *testing.T) {
+	_, err := NewEncryptedFileTokenStorage(filepath.Join(t.TempDir(), "token.enc"), []byte("short"))
+	if !IsValidationError(err) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestNewClient_LoadsTokenFromFileStorage(t *testing.T) {
+	storage, err := NewFileTokenStorage(filepath.Join(t.TempDir(), "token.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Store(testTokenInfo()); err != nil {
+		t.Fatal(err)
+	}
+
+	config := testClientConfig(t, http.NotFoundHandler())
+	config.TokenStorage = storage
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.GetAccessToken() != "secret-access-token" {
+		t.Errorf("expected token to be restored from file, got %q", client.GetAccessToken())
+	}
+}
@@ -2,6 +2,7 @@ package threads
 
 import (
 	"encoding/json"
+	"io"
 	"strings"
 	"time"
 )
@@ -196,6 +197,12 @@ type ImagePostContent struct {
 	IsSpoilerMedia bool `json:"is_spoiler_media,omitempty"`
 	// EnableReplyApprovals enables reply approvals on the post; replies must be approved before publishing
 	EnableReplyApprovals bool `json:"enable_reply_approvals,omitempty"`
+	// ImagePath is a local image file to post instead of ImageURL
+	// It is uploaded with Config.MediaHost, which must be set
+	ImagePath string `json:"image_path,omitempty"`
+	// ImageReader provides the image instead of ImageURL or ImagePath
+	// It is uploaded with Config.MediaHost and cannot be scheduled
+	ImageReader io.Reader `json:"-"`
 }
 
 // VideoPostContent represents content for video posts.
@@ -219,6 +226,12 @@ type VideoPostContent struct {
 	IsSpoilerMedia bool `json:"is_spoiler_media,omitempty"`
 	// EnableReplyApprovals enables reply approvals on the post; replies must be approved before publishing
 	EnableReplyApprovals bool `json:"enable_reply_approvals,omitempty"`
+	// VideoPath is a local video file to post instead of VideoURL
+	// It is uploaded with Config.MediaHost, which must be set
+	VideoPath string `json:"video_path,omitempty"`
+	// VideoReader provides the video instead of VideoURL or VideoPath
+	// It is uploaded with Config.MediaHost and cannot be scheduled
+	VideoReader io.Reader `json:"-"`
 }
 
 // CarouselPostContent represents content for carousel posts.
@@ -241,6 +254,19 @@ type CarouselPostContent struct {
 	IsSpoilerMedia bool `json:"is_spoiler_media,omitempty"`
 	// EnableReplyApprovals enables reply approvals on the post; replies must be approved before publishing
 	EnableReplyApprovals bool `json:"enable_reply_approvals,omitempty"`
+	// Items are images and videos to create carousel item containers for,
+	// placed after Children. Local items are uploaded with Config.MediaHost
+	Items []CarouselItem `json:"items,omitempty"`
+}
+
+// CarouselItem is an image or video in a carousel, given by exactly one of
+// URL, Path and Reader.
+type CarouselItem struct {
+	MediaType string    `json:"media_type"` // MediaTypeImage or MediaTypeVideo
+	URL       string    `json:"url,omitempty"`
+	Path      string    `json:"path,omitempty"` // Local file, uploaded with Config.MediaHost
+	Reader    io.Reader `json:"-"`              // Content, uploaded with Config.MediaHost
+	AltText   string    `json:"alt_text,omitempty"`
 }
 
 // ReplyControl defines who can reply to a post
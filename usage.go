@@ -0,0 +1,127 @@
+package threads
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// AppUsage is the app-level usage reported in the X-App-Usage header.
+// Each value is the percentage (0-100) of the app's hourly allowance used.
+type AppUsage struct {
+	CallCount    int `json:"call_count"`
+	TotalCPUTime int `json:"total_cputime"`
+	TotalTime    int `json:"total_time"`
+}
+
+// BusinessUseCaseUsage is one entry of the X-Business-Use-Case-Usage header,
+// reporting usage for a single business object and use case type.
+type BusinessUseCaseUsage struct {
+	Type         string `json:"type"`
+	CallCount    int    `json:"call_count"`
+	TotalCPUTime int    `json:"total_cputime"`
+	TotalTime    int    `json:"total_time"`
+
+	// EstimatedTimeToRegainAccess is how long calls for this use case stay
+	// throttled. The header reports minutes; zero means not throttled.
+	EstimatedTimeToRegainAccess time.Duration `json:"estimated_time_to_regain_access"`
+}
+
+// UnmarshalJSON converts estimated_time_to_regain_access from minutes.
+func (b *BusinessUseCaseUsage) UnmarshalJSON(data []byte) error {
+	type alias BusinessUseCaseUsage
+	aux := struct {
+		*alias
+		EstimatedTimeToRegainAccess int64 `json:"estimated_time_to_regain_access"`
+	}{alias: (*alias)(b)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	b.EstimatedTimeToRegainAccess = time.Duration(aux.EstimatedTimeToRegainAccess) * time.Minute
+	return nil
+}
+
+// MarshalJSON writes estimated_time_to_regain_access in minutes, matching
+// the header format.
+func (b BusinessUseCaseUsage) MarshalJSON() ([]byte, error) {
+	type alias BusinessUseCaseUsage
+	return json.Marshal(struct {
+		alias
+		EstimatedTimeToRegainAccess int64 `json:"estimated_time_to_regain_access"`
+	}{alias: alias(b), EstimatedTimeToRegainAccess: int64(b.EstimatedTimeToRegainAccess / time.Minute)})
+}
+
+// APIUsage is the Graph API usage reported on a response.
+// Either field may be nil when the corresponding header was absent.
+type APIUsage struct {
+	App *AppUsage `json:"app,omitempty"`
+
+	// BusinessUseCase maps business object IDs to their per-use-case usage.
+	BusinessUseCase map[string][]BusinessUseCaseUsage `json:"business_use_case,omitempty"`
+}
+
+// MaxPercent returns the highest usage percentage across all reported
+// metrics. Throttling starts when any metric reaches 100.
+func (u *APIUsage) MaxPercent() int {
+	if u == nil {
+		return 0
+	}
+	highest := 0
+	if u.App != nil {
+		highest = max(highest, u.App.CallCount, u.App.TotalCPUTime, u.App.TotalTime)
+	}
+	for _, entries := range u.BusinessUseCase {
+		for _, e := range entries {
+			highest = max(highest, e.CallCount, e.TotalCPUTime, e.TotalTime)
+		}
+	}
+	return highest
+}
+
+// RegainAccessIn returns the longest estimated time to regain access across
+// all business use cases, or zero if none are throttled.
+func (u *APIUsage) RegainAccessIn() time.Duration {
+	if u == nil {
+		return 0
+	}
+	var longest time.Duration
+	for _, entries := range u.BusinessUseCase {
+		for _, e := range entries {
+			if e.EstimatedTimeToRegainAccess > longest {
+				longest = e.EstimatedTimeToRegainAccess
+			}
+		}
+	}
+	return longest
+}
+
+// appThrottled reports whether X-App-Usage shows the app, and therefore
+// every account using it, is throttled.
+func (u *APIUsage) appThrottled() bool {
+	return u != nil && u.App != nil && max(u.App.CallCount, u.App.TotalCPUTime, u.App.TotalTime) >= 100
+}
+
+// parseUsageHeaders extracts X-App-Usage and X-Business-Use-Case-Usage.
+// Malformed headers are ignored. Returns nil if neither header is present.
+func parseUsageHeaders(headers http.Header) *APIUsage {
+	usage := &APIUsage{}
+
+	if raw := headers.Get("X-App-Usage"); raw != "" {
+		var app AppUsage
+		if err := json.Unmarshal([]byte(raw), &app); err == nil {
+			usage.App = &app
+		}
+	}
+
+	if raw := headers.Get("X-Business-Use-Case-Usage"); raw != "" {
+		var buc map[string][]BusinessUseCaseUsage
+		if err := json.Unmarshal([]byte(raw), &buc); err == nil && len(buc) > 0 {
+			usage.BusinessUseCase = buc
+		}
+	}
+
+	if usage.App == nil && usage.BusinessUseCase == nil {
+		return nil
+	}
+	return usage
+}
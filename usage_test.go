@@ -0,0 +1,101 @@
+package threads
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseUsageHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-App-Usage", `{"call_count":28,"total_time":25,"total_cputime":41}`)
+	headers.Set("X-Business-Use-Case-Usage", `{"12345":[{"type":"threads","call_count":97,"total_cputime":10,"total_time":12,"estimated_time_to_regain_access":3}]}`)
+
+	usage := parseUsageHeaders(headers)
+	if usage == nil || usage.App == nil {
+		t.Fatal("expected app usage to be parsed")
+	}
+	if usage.App.CallCount != 28 || usage.App.TotalCPUTime != 41 || usage.App.TotalTime != 25 {
+		t.Errorf("unexpected app usage %+v", usage.App)
+	}
+	entries := usage.BusinessUseCase["12345"]
+	if len(entries) != 1 || entries[0].Type != "threads" {
+		t.Fatalf("unexpected business use case usage %+v", usage.BusinessUseCase)
+	}
+	if entries[0].EstimatedTimeToRegainAccess != 3*time.Minute {
+		t.Errorf("expected regain time in minutes, got %s", entries[0].EstimatedTimeToRegainAccess)
+	}
+	if usage.MaxPercent() != 97 || usage.RegainAccessIn() != 3*time.Minute {
+		t.Errorf("expected max 97%% and 3m regain, got %d%% and %s", usage.MaxPercent(), usage.RegainAccessIn())
+	}
+
+	data, err := json.Marshal(entries[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip BusinessUseCaseUsage
+	if err := json.Unmarshal(data, &roundTrip); err != nil || roundTrip != entries[0] {
+		t.Errorf("expected JSON round trip to preserve usage, got %+v (%v)", roundTrip, err)
+	}
+
+	malformed := http.Header{}
+	malformed.Set("X-App-Usage", "not json")
+	if parseUsageHeaders(malformed) != nil {
+		t.Error("expected malformed header to be ignored")
+	}
+}
+
+func TestRateLimiter_UsageDrivesNearLimitAndWait(t *testing.T) {
+	rl := NewRateLimiter(&RateLimiterConfig{InitialLimit: 100})
+
+	rl.UpdateFromHeaders(&RateLimitInfo{Usage: &APIUsage{App: &AppUsage{CallCount: 85}}})
+	if !rl.IsNearLimit(0.8) {
+		t.Error("expected 85% app usage to be near an 80% threshold")
+	}
+	if rl.ShouldWait() {
+		t.Error("usage below 100% must not block requests")
+	}
+	if status := rl.GetStatus(); status.Remaining != 100 || status.Usage == nil {
+		t.Errorf("expected usage-only headers to leave the window untouched, got %+v", status)
+	}
+
+	rl.UpdateUsage(&APIUsage{BusinessUseCase: map[string][]BusinessUseCaseUsage{
+		"12345": {{Type: "threads", CallCount: 100, EstimatedTimeToRegainAccess: 5 * time.Minute}},
+	}})
+	if !rl.ShouldWait() {
+		t.Fatal("expected throttled usage to make the limiter wait")
+	}
+	if resetIn := rl.GetStatus().ResetIn; resetIn < 4*time.Minute || resetIn > 5*time.Minute {
+		t.Errorf("expected reset in about 5m, got %s", resetIn)
+	}
+}
+
+func TestClient_ExposesUsageFromResponses(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-App-Usage", `{"call_count":42,"total_time":5,"total_cputime":3}`)
+		_, _ = w.Write([]byte(`{"id":"12345","username":"tester"}`))
+	}
+	client := testClient(t, http.HandlerFunc(handler))
+
+	resp, err := client.httpClient.GETWithContext(context.Background(), "/me", nil, client.GetAccessToken())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Usage == nil || resp.Usage.App.CallCount != 42 {
+		t.Errorf("expected usage on the response, got %+v", resp.Usage)
+	}
+
+	if _, err := client.GetMe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	status := client.GetRateLimitStatus()
+	if status.Usage == nil || status.Usage.MaxPercent() != 42 {
+		t.Errorf("expected usage on the rate limit status, got %+v", status.Usage)
+	}
+	if !client.IsNearRateLimit(0.4) || client.IsNearRateLimit(0.5) {
+		t.Error("expected IsNearRateLimit to follow reported usage")
+	}
+}
@@ -483,6 +483,7 @@ func (cv *ConfigValidator) Validate(c *Config) error {
 		cv.validateScopes,
 		cv.validateHTTPSettings,
 		cv.validateRetryConfig,
+		cv.validateContainerPolling,
 	}
 
 	for _, validator := range validators {
@@ -589,5 +590,18 @@ func (cv *ConfigValidator) validateRetryConfig(c *Config) error {
 		return fmt.Errorf("RetryConfig.InitialDelay cannot be greater than MaxDelay")
 	}
 
+	if c.RetryConfig.Jitter < 0 || c.RetryConfig.Jitter > 1 {
+		return fmt.Errorf("RetryConfig.Jitter must be between 0 and 1")
+	}
+
 	return nil
 }
+
+// validateContainerPolling validates container polling configuration
+func (cv *ConfigValidator) validateContainerPolling(c *Config) error {
+	if c.ContainerPolling == nil {
+		return nil
+	}
+
+	return c.ContainerPolling.validate()
+}